@@ -0,0 +1,206 @@
+package podcast
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://tools.ietf.org/html/rfc4287
+//
+
+type atomFeed struct {
+	XMLName    xml.Name        `xml:"feed"`
+	XMLNS      string          `xml:"xmlns,attr"`
+	ID         string          `xml:"id"`
+	Title      string          `xml:"title"`
+	Subtitle   string          `xml:"subtitle,omitempty"`
+	Updated    string          `xml:"updated"`
+	Links      []*atomLinkElem `xml:"link"`
+	Author     *atomPerson     `xml:"author,omitempty"`
+	Categories []*atomCategory `xml:"category"`
+	Generator  string          `xml:"generator,omitempty"`
+	Logo       string          `xml:"logo,omitempty"`
+	Rights     string          `xml:"rights,omitempty"`
+	Entries    []*atomEntry    `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string          `xml:"id"`
+	Title      string          `xml:"title"`
+	Updated    string          `xml:"updated"`
+	Published  string          `xml:"published,omitempty"`
+	Links      []*atomLinkElem `xml:"link"`
+	Author     *atomPerson     `xml:"author,omitempty"`
+	Categories []*atomCategory `xml:"category"`
+	Summary    *atomText       `xml:"summary,omitempty"`
+	Content    *atomText       `xml:"content,omitempty"`
+}
+
+// atomLinkElem is an Atom link in the default namespace, unlike AtomLink
+// which is embedded in RSS.
+type atomLinkElem struct {
+	HREF   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// EncodeAtom writes the bytes to the io.Writer stream in Atom 1.0
+// specification, mapping the Podcast to the feed and each Item to an
+// entry.
+//
+// Enclosures are written as links with rel="enclosure" and all dates are
+// converted to RFC 3339.  The feed id is the AtomLink href, or the Link
+// when not set.  The feed's updated date is taken from LastBuildDate, then
+// PubDate, then the Podcast.Now clock.
+//
+// Each entry id is the Item's GUID, or else its Enclosure URL or Link.  An
+// id that is not an absolute IRI, such as "episode-1", is written as a
+// urn:uuid of the feed id and the GUID.
+func (p *Podcast) EncodeAtom(w io.Writer) error {
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return errors.Wrap(err, "podcast.EncodeAtom: w.Write return error")
+	}
+	return p.encode(w, p.atomFeed())
+}
+
+func (p *Podcast) atomFeed() *atomFeed {
+	f := &atomFeed{
+		XMLNS:     atomNS,
+		ID:        p.Link,
+		Title:     p.Title,
+		Subtitle:  p.Description,
+		Updated:   p.atomUpdated(),
+		Author:    p.atomAuthor(),
+		Generator: p.Generator,
+		Rights:    p.Copyright,
+	}
+	if p.AtomLink != nil && len(p.AtomLink.HREF) > 0 {
+		f.ID = p.AtomLink.HREF
+	}
+	if len(p.Link) > 0 {
+		f.Links = append(f.Links, &atomLinkElem{HREF: p.Link, Rel: "alternate"})
+	}
+	for _, l := range p.AtomLinks {
+		f.Links = append(f.Links, &atomLinkElem{HREF: l.HREF, Rel: l.Rel})
+	}
+	if p.IImage != nil {
+		f.Logo = p.IImage.HREF
+	}
+	for _, c := range p.ICategories {
+		f.Categories = append(f.Categories, &atomCategory{Term: c.Text})
+	}
+	for _, i := range p.Items {
+		f.Entries = append(f.Entries, p.atomEntry(i, f.ID))
+	}
+	return f
+}
+
+func (p *Podcast) atomUpdated() string {
+	for _, d := range []string{p.LastBuildDate, p.PubDate} {
+		if t := parseDate(d); t != nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return p.now().Format(time.RFC3339)
+}
+
+func (p *Podcast) atomAuthor() *atomPerson {
+	switch {
+	case p.IOwner != nil && len(p.IOwner.Email) > 0:
+		return &atomPerson{Name: p.IOwner.Name, Email: p.IOwner.Email}
+	case len(p.ManagingEditor) > 0:
+		return atomPersonFormatted(p.ManagingEditor)
+	case len(p.IAuthor) > 0:
+		return atomPersonFormatted(p.IAuthor)
+	}
+	return nil
+}
+
+func (p *Podcast) atomEntry(i *Item, feedID string) *atomEntry {
+	e := &atomEntry{
+		ID:      atomID(feedID, itemID(i)),
+		Title:   i.Title,
+		Updated: p.atomItemDate(i),
+	}
+	e.Published = e.Updated
+	if len(i.Link) > 0 {
+		e.Links = append(e.Links, &atomLinkElem{HREF: i.Link, Rel: "alternate"})
+	}
+	if i.Enclosure != nil {
+		e.Links = append(e.Links, &atomLinkElem{
+			HREF:   i.Enclosure.URL,
+			Rel:    "enclosure",
+			Type:   i.Enclosure.Type.String(),
+			Length: strconv.FormatInt(i.Enclosure.Length, 10),
+		})
+	}
+	if i.Author != nil {
+		e.Author = atomPersonFormatted(parseAuthorNameEmail(i.Author))
+	}
+	if len(i.Category) > 0 {
+		e.Categories = append(e.Categories, &atomCategory{Term: i.Category})
+	}
+	if len(i.Description) > 0 {
+		e.Summary = &atomText{Type: "html", Text: i.Description}
+	}
+	if i.ISummary != nil {
+		e.Content = &atomText{Type: "html", Text: i.ISummary.Text}
+	}
+	return e
+}
+
+// atomNamespace is the RFC 4122 URL namespace of the urn:uuid entry ids.
+var atomNamespace = [16]byte{
+	0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+	0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8,
+}
+
+// atomID returns the id of the entry when it is an absolute IRI, or else
+// a urn:uuid unique to the feed.
+func atomID(feedID, id string) string {
+	if u, err := url.Parse(id); err == nil && u.IsAbs() {
+		return id
+	}
+	return "urn:uuid:" + uuidV5(atomNamespace, feedID+"#"+id)
+}
+
+func (p *Podcast) atomItemDate(i *Item) string {
+	t := i.PubDate
+	if t == nil || t.IsZero() {
+		t = parseDate(i.PubDateFormatted)
+	}
+	if t == nil {
+		return p.atomUpdated()
+	}
+	return t.Format(time.RFC3339)
+}
+
+// atomPersonFormatted converts an author formatted as "email (name)" into
+// an Atom person, using the email as the name when no name is given.
+func atomPersonFormatted(s string) *atomPerson {
+	a := parseAuthorFormatted(s)
+	if len(a.Name) == 0 {
+		return &atomPerson{Name: a.Email}
+	}
+	return &atomPerson{Name: a.Name, Email: a.Email}
+}
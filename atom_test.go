@@ -0,0 +1,125 @@
+package podcast_test
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeAtomWriterError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "desc", "Link", nil, nil)
+
+	// act
+	err := p.EncodeAtom(&errWriter{})
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "w.Write return error")
+}
+
+func TestEncodeAtomDatesFromClock(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "desc", nil, nil,
+		podcast.WithStrictDates(),
+		podcast.WithClock(func() time.Time { return createdDate }))
+	p.Items = append(p.Items, &podcast.Item{GUID: "1", Title: "t"})
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeAtom(b)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "<updated>2017-02-01T08:21:52Z</updated>")
+	assert.Contains(t, b.String(), "<id>http://example.com/</id>")
+	assert.NotContains(t, b.String(), "<author>")
+}
+
+func TestEncodeAtomItemFields(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "desc", &pubDate, &updatedDate)
+	p.IAuthor = "me@janedoe.com"
+	p.AddCategory("Technology", nil)
+	p.Items = append(p.Items, &podcast.Item{
+		GUID:             "1",
+		Title:            "t",
+		Category:         "News",
+		PubDateFormatted: createdDate.Format(time.RFC1123Z),
+		ISummary:         &podcast.ISummary{Text: "<b>rich</b>"},
+	})
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeAtom(b)
+
+	// assert
+	assert.NoError(t, err)
+	s := b.String()
+	assert.Contains(t, s, "<name>me@janedoe.com</name>")
+	assert.Contains(t, s, `<category term="Technology"></category>`)
+	assert.Contains(t, s, `<category term="News"></category>`)
+	assert.Contains(t, s, "<published>2017-02-01T08:21:52Z</published>")
+	assert.Contains(t, s, `<content type="html">&lt;b&gt;rich&lt;/b&gt;</content>`)
+}
+
+func TestEncodeAtomOwnerAuthor(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "desc", &pubDate, &updatedDate)
+	p.AddAuthor("Jane Doe", "me@janedoe.com")
+	p.IOwner = &podcast.Author{Name: "Owner", Email: "owner@janedoe.com"}
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeAtom(b)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "<name>Owner</name>")
+}
+
+func TestEncodeAtomEntryIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "desc", &pubDate, &updatedDate)
+	p.Items = append(p.Items,
+		&podcast.Item{Title: "url", GUID: "http://example.com/1"},
+		&podcast.Item{Title: "urn", GUID: "urn:isbn:0451450523"},
+		&podcast.Item{Title: "enclosure",
+			Enclosure: &podcast.Enclosure{URL: "http://example.com/2.mp3", Type: podcast.MP3}},
+		&podcast.Item{Title: "plain", GUID: "episode-1"},
+	)
+	other := podcast.New("title", "http://other.example.com/", "desc", &pubDate, &updatedDate)
+	other.Items = append(other.Items, &podcast.Item{Title: "plain", GUID: "episode-1"})
+	b, ob := &bytes.Buffer{}, &bytes.Buffer{}
+
+	// act
+	err := p.EncodeAtom(b)
+	oerr := other.EncodeAtom(ob)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, oerr)
+	s := b.String()
+	assert.Contains(t, s, "<id>http://example.com/1</id>")
+	assert.Contains(t, s, "<id>urn:isbn:0451450523</id>")
+	assert.Contains(t, s, "<id>http://example.com/2.mp3</id>")
+	assert.NotContains(t, s, "<id>episode-1</id>")
+	urn := regexp.MustCompile(`<id>urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}</id>`)
+	id := urn.FindString(s)
+	assert.NotEmpty(t, id)
+	assert.NotEqual(t, id, urn.FindString(ob.String()), "unique to the feed")
+}
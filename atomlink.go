@@ -7,5 +7,5 @@ type AtomLink struct {
 	XMLName xml.Name `xml:"atom:link"`
 	HREF    string   `xml:"href,attr"`
 	Rel     string   `xml:"rel,attr"`
-	Type    string   `xml:"type,attr"`
+	Type    string   `xml:"type,attr,omitempty"`
 }
@@ -0,0 +1,401 @@
+package podcast
+
+import "github.com/pkg/errors"
+
+// AppleCategory is a category or subcategory of the Apple Podcasts
+// taxonomy.
+//
+// https://podcasters.apple.com/support/1691-apple-podcasts-categories
+const (
+	CategoryArts                 AppleCategory = "Arts"
+	CategoryArtsBooks            AppleCategory = "Books"
+	CategoryArtsDesign           AppleCategory = "Design"
+	CategoryArtsFashionAndBeauty AppleCategory = "Fashion & Beauty"
+	CategoryArtsFood             AppleCategory = "Food"
+	CategoryArtsPerformingArts   AppleCategory = "Performing Arts"
+	CategoryArtsVisualArts       AppleCategory = "Visual Arts"
+
+	CategoryBusiness                 AppleCategory = "Business"
+	CategoryBusinessCareers          AppleCategory = "Careers"
+	CategoryBusinessEntrepreneurship AppleCategory = "Entrepreneurship"
+	CategoryBusinessInvesting        AppleCategory = "Investing"
+	CategoryBusinessManagement       AppleCategory = "Management"
+	CategoryBusinessMarketing        AppleCategory = "Marketing"
+	CategoryBusinessNonProfit        AppleCategory = "Non-Profit"
+
+	CategoryComedy           AppleCategory = "Comedy"
+	CategoryComedyInterviews AppleCategory = "Comedy Interviews"
+	CategoryComedyImprov     AppleCategory = "Improv"
+	CategoryComedyStandUp    AppleCategory = "Stand-Up"
+
+	CategoryEducation                 AppleCategory = "Education"
+	CategoryEducationCourses          AppleCategory = "Courses"
+	CategoryEducationHowTo            AppleCategory = "How To"
+	CategoryEducationLanguageLearning AppleCategory = "Language Learning"
+	CategoryEducationSelfImprovement  AppleCategory = "Self-Improvement"
+
+	CategoryFiction               AppleCategory = "Fiction"
+	CategoryFictionComedyFiction  AppleCategory = "Comedy Fiction"
+	CategoryFictionDrama          AppleCategory = "Drama"
+	CategoryFictionScienceFiction AppleCategory = "Science Fiction"
+
+	CategoryGovernment AppleCategory = "Government"
+
+	CategoryHistory AppleCategory = "History"
+
+	CategoryHealthAndFitness                  AppleCategory = "Health & Fitness"
+	CategoryHealthAndFitnessAlternativeHealth AppleCategory = "Alternative Health"
+	CategoryHealthAndFitnessFitness           AppleCategory = "Fitness"
+	CategoryHealthAndFitnessMedicine          AppleCategory = "Medicine"
+	CategoryHealthAndFitnessMentalHealth      AppleCategory = "Mental Health"
+	CategoryHealthAndFitnessNutrition         AppleCategory = "Nutrition"
+	CategoryHealthAndFitnessSexuality         AppleCategory = "Sexuality"
+
+	CategoryKidsAndFamily                 AppleCategory = "Kids & Family"
+	CategoryKidsAndFamilyEducationForKids AppleCategory = "Education for Kids"
+	CategoryKidsAndFamilyParenting        AppleCategory = "Parenting"
+	CategoryKidsAndFamilyPetsAndAnimals   AppleCategory = "Pets & Animals"
+	CategoryKidsAndFamilyStoriesForKids   AppleCategory = "Stories for Kids"
+
+	CategoryLeisure                  AppleCategory = "Leisure"
+	CategoryLeisureAnimationAndManga AppleCategory = "Animation & Manga"
+	CategoryLeisureAutomotive        AppleCategory = "Automotive"
+	CategoryLeisureAviation          AppleCategory = "Aviation"
+	CategoryLeisureCrafts            AppleCategory = "Crafts"
+	CategoryLeisureGames             AppleCategory = "Games"
+	CategoryLeisureHobbies           AppleCategory = "Hobbies"
+	CategoryLeisureHomeAndGarden     AppleCategory = "Home & Garden"
+	CategoryLeisureVideoGames        AppleCategory = "Video Games"
+
+	CategoryMusic           AppleCategory = "Music"
+	CategoryMusicCommentary AppleCategory = "Music Commentary"
+	CategoryMusicHistory    AppleCategory = "Music History"
+	CategoryMusicInterviews AppleCategory = "Music Interviews"
+
+	CategoryNews                  AppleCategory = "News"
+	CategoryNewsBusinessNews      AppleCategory = "Business News"
+	CategoryNewsDailyNews         AppleCategory = "Daily News"
+	CategoryNewsEntertainmentNews AppleCategory = "Entertainment News"
+	CategoryNewsCommentary        AppleCategory = "News Commentary"
+	CategoryNewsPolitics          AppleCategory = "Politics"
+	CategoryNewsSportsNews        AppleCategory = "Sports News"
+	CategoryNewsTechNews          AppleCategory = "Tech News"
+
+	CategoryReligionAndSpirituality             AppleCategory = "Religion & Spirituality"
+	CategoryReligionAndSpiritualityBuddhism     AppleCategory = "Buddhism"
+	CategoryReligionAndSpiritualityChristianity AppleCategory = "Christianity"
+	CategoryReligionAndSpiritualityHinduism     AppleCategory = "Hinduism"
+	CategoryReligionAndSpiritualityIslam        AppleCategory = "Islam"
+	CategoryReligionAndSpiritualityJudaism      AppleCategory = "Judaism"
+	CategoryReligionAndSpiritualityReligion     AppleCategory = "Religion"
+	CategoryReligionAndSpiritualitySpirituality AppleCategory = "Spirituality"
+
+	CategoryScience                AppleCategory = "Science"
+	CategoryScienceAstronomy       AppleCategory = "Astronomy"
+	CategoryScienceChemistry       AppleCategory = "Chemistry"
+	CategoryScienceEarthSciences   AppleCategory = "Earth Sciences"
+	CategoryScienceLifeSciences    AppleCategory = "Life Sciences"
+	CategoryScienceMathematics     AppleCategory = "Mathematics"
+	CategoryScienceNaturalSciences AppleCategory = "Natural Sciences"
+	CategoryScienceNature          AppleCategory = "Nature"
+	CategorySciencePhysics         AppleCategory = "Physics"
+	CategoryScienceSocialSciences  AppleCategory = "Social Sciences"
+
+	CategorySocietyAndCulture                 AppleCategory = "Society & Culture"
+	CategorySocietyAndCultureDocumentary      AppleCategory = "Documentary"
+	CategorySocietyAndCulturePersonalJournals AppleCategory = "Personal Journals"
+	CategorySocietyAndCulturePhilosophy       AppleCategory = "Philosophy"
+	CategorySocietyAndCulturePlacesAndTravel  AppleCategory = "Places & Travel"
+	CategorySocietyAndCultureRelationships    AppleCategory = "Relationships"
+
+	CategorySports              AppleCategory = "Sports"
+	CategorySportsBaseball      AppleCategory = "Baseball"
+	CategorySportsBasketball    AppleCategory = "Basketball"
+	CategorySportsCricket       AppleCategory = "Cricket"
+	CategorySportsFantasySports AppleCategory = "Fantasy Sports"
+	CategorySportsFootball      AppleCategory = "Football"
+	CategorySportsGolf          AppleCategory = "Golf"
+	CategorySportsHockey        AppleCategory = "Hockey"
+	CategorySportsRugby         AppleCategory = "Rugby"
+	CategorySportsRunning       AppleCategory = "Running"
+	CategorySportsSoccer        AppleCategory = "Soccer"
+	CategorySportsSwimming      AppleCategory = "Swimming"
+	CategorySportsTennis        AppleCategory = "Tennis"
+	CategorySportsVolleyball    AppleCategory = "Volleyball"
+	CategorySportsWilderness    AppleCategory = "Wilderness"
+	CategorySportsWrestling     AppleCategory = "Wrestling"
+
+	CategoryTechnology AppleCategory = "Technology"
+
+	CategoryTrueCrime AppleCategory = "True Crime"
+
+	CategoryTVAndFilm               AppleCategory = "TV & Film"
+	CategoryTVAndFilmAfterShows     AppleCategory = "After Shows"
+	CategoryTVAndFilmFilmHistory    AppleCategory = "Film History"
+	CategoryTVAndFilmFilmInterviews AppleCategory = "Film Interviews"
+	CategoryTVAndFilmFilmReviews    AppleCategory = "Film Reviews"
+	CategoryTVAndFilmTVReviews      AppleCategory = "TV Reviews"
+)
+
+// appleCategories is the 2019 Apple Podcasts taxonomy of top-level
+// categories and their subcategories.
+var appleCategories = map[AppleCategory][]AppleCategory{
+	CategoryArts: {
+		CategoryArtsBooks,
+		CategoryArtsDesign,
+		CategoryArtsFashionAndBeauty,
+		CategoryArtsFood,
+		CategoryArtsPerformingArts,
+		CategoryArtsVisualArts,
+	},
+	CategoryBusiness: {
+		CategoryBusinessCareers,
+		CategoryBusinessEntrepreneurship,
+		CategoryBusinessInvesting,
+		CategoryBusinessManagement,
+		CategoryBusinessMarketing,
+		CategoryBusinessNonProfit,
+	},
+	CategoryComedy: {
+		CategoryComedyInterviews,
+		CategoryComedyImprov,
+		CategoryComedyStandUp,
+	},
+	CategoryEducation: {
+		CategoryEducationCourses,
+		CategoryEducationHowTo,
+		CategoryEducationLanguageLearning,
+		CategoryEducationSelfImprovement,
+	},
+	CategoryFiction: {
+		CategoryFictionComedyFiction,
+		CategoryFictionDrama,
+		CategoryFictionScienceFiction,
+	},
+	CategoryGovernment: nil,
+	CategoryHistory:    nil,
+	CategoryHealthAndFitness: {
+		CategoryHealthAndFitnessAlternativeHealth,
+		CategoryHealthAndFitnessFitness,
+		CategoryHealthAndFitnessMedicine,
+		CategoryHealthAndFitnessMentalHealth,
+		CategoryHealthAndFitnessNutrition,
+		CategoryHealthAndFitnessSexuality,
+	},
+	CategoryKidsAndFamily: {
+		CategoryKidsAndFamilyEducationForKids,
+		CategoryKidsAndFamilyParenting,
+		CategoryKidsAndFamilyPetsAndAnimals,
+		CategoryKidsAndFamilyStoriesForKids,
+	},
+	CategoryLeisure: {
+		CategoryLeisureAnimationAndManga,
+		CategoryLeisureAutomotive,
+		CategoryLeisureAviation,
+		CategoryLeisureCrafts,
+		CategoryLeisureGames,
+		CategoryLeisureHobbies,
+		CategoryLeisureHomeAndGarden,
+		CategoryLeisureVideoGames,
+	},
+	CategoryMusic: {
+		CategoryMusicCommentary,
+		CategoryMusicHistory,
+		CategoryMusicInterviews,
+	},
+	CategoryNews: {
+		CategoryNewsBusinessNews,
+		CategoryNewsDailyNews,
+		CategoryNewsEntertainmentNews,
+		CategoryNewsCommentary,
+		CategoryNewsPolitics,
+		CategoryNewsSportsNews,
+		CategoryNewsTechNews,
+	},
+	CategoryReligionAndSpirituality: {
+		CategoryReligionAndSpiritualityBuddhism,
+		CategoryReligionAndSpiritualityChristianity,
+		CategoryReligionAndSpiritualityHinduism,
+		CategoryReligionAndSpiritualityIslam,
+		CategoryReligionAndSpiritualityJudaism,
+		CategoryReligionAndSpiritualityReligion,
+		CategoryReligionAndSpiritualitySpirituality,
+	},
+	CategoryScience: {
+		CategoryScienceAstronomy,
+		CategoryScienceChemistry,
+		CategoryScienceEarthSciences,
+		CategoryScienceLifeSciences,
+		CategoryScienceMathematics,
+		CategoryScienceNaturalSciences,
+		CategoryScienceNature,
+		CategorySciencePhysics,
+		CategoryScienceSocialSciences,
+	},
+	CategorySocietyAndCulture: {
+		CategorySocietyAndCultureDocumentary,
+		CategorySocietyAndCulturePersonalJournals,
+		CategorySocietyAndCulturePhilosophy,
+		CategorySocietyAndCulturePlacesAndTravel,
+		CategorySocietyAndCultureRelationships,
+	},
+	CategorySports: {
+		CategorySportsBaseball,
+		CategorySportsBasketball,
+		CategorySportsCricket,
+		CategorySportsFantasySports,
+		CategorySportsFootball,
+		CategorySportsGolf,
+		CategorySportsHockey,
+		CategorySportsRugby,
+		CategorySportsRunning,
+		CategorySportsSoccer,
+		CategorySportsSwimming,
+		CategorySportsTennis,
+		CategorySportsVolleyball,
+		CategorySportsWilderness,
+		CategorySportsWrestling,
+	},
+	CategoryTechnology: nil,
+	CategoryTrueCrime:  nil,
+	CategoryTVAndFilm: {
+		CategoryTVAndFilmAfterShows,
+		CategoryTVAndFilmFilmHistory,
+		CategoryTVAndFilmFilmInterviews,
+		CategoryTVAndFilmFilmReviews,
+		CategoryTVAndFilmTVReviews,
+	},
+}
+
+// AppleCategory is a category or subcategory of the Apple Podcasts
+// taxonomy, as used for the itunes:category tags.
+type AppleCategory string
+
+// String returns the text of the AppleCategory.
+func (c AppleCategory) String() string {
+	return string(c)
+}
+
+// Subcategories returns the subcategories allowed under the category, or
+// nil if the category has none or is not a top-level category.
+func (c AppleCategory) Subcategories() []AppleCategory {
+	return appleCategories[c]
+}
+
+// legacyAppleCategories maps the pre-2019 Apple categories, keyed as
+// "Category/Subcategory", to their modern replacements.
+var legacyAppleCategories = map[string][2]AppleCategory{
+	"Arts/Literature":                           {CategoryArts, CategoryArtsBooks},
+	"Business/Business News":                    {CategoryNews, CategoryNewsBusinessNews},
+	"Business/Management & Marketing":           {CategoryBusiness, CategoryBusinessManagement},
+	"Business/Shopping":                         {CategoryBusiness, ""},
+	"Education/Education Technology":            {CategoryEducation, ""},
+	"Education/Educational Technology":          {CategoryEducation, ""},
+	"Education/Higher Education":                {CategoryEducation, ""},
+	"Education/K-12":                            {CategoryKidsAndFamily, CategoryKidsAndFamilyEducationForKids},
+	"Education/Language Courses":                {CategoryEducation, CategoryEducationLanguageLearning},
+	"Education/Training":                        {CategoryEducation, CategoryEducationCourses},
+	"Games & Hobbies/":                          {CategoryLeisure, ""},
+	"Games & Hobbies/Automotive":                {CategoryLeisure, CategoryLeisureAutomotive},
+	"Games & Hobbies/Aviation":                  {CategoryLeisure, CategoryLeisureAviation},
+	"Games & Hobbies/Hobbies":                   {CategoryLeisure, CategoryLeisureHobbies},
+	"Games & Hobbies/Other Games":               {CategoryLeisure, CategoryLeisureGames},
+	"Games & Hobbies/Video Games":               {CategoryLeisure, CategoryLeisureVideoGames},
+	"Government & Organizations/":               {CategoryGovernment, ""},
+	"Government & Organizations/Local":          {CategoryGovernment, ""},
+	"Government & Organizations/National":       {CategoryGovernment, ""},
+	"Government & Organizations/Non-Profit":     {CategoryBusiness, CategoryBusinessNonProfit},
+	"Government & Organizations/Regional":       {CategoryGovernment, ""},
+	"Health/":                                   {CategoryHealthAndFitness, ""},
+	"Health/Alternative Health":                 {CategoryHealthAndFitness, CategoryHealthAndFitnessAlternativeHealth},
+	"Health/Fitness & Nutrition":                {CategoryHealthAndFitness, CategoryHealthAndFitnessFitness},
+	"Health/Self-Help":                          {CategoryEducation, CategoryEducationSelfImprovement},
+	"Health/Sexuality":                          {CategoryHealthAndFitness, CategoryHealthAndFitnessSexuality},
+	"News & Politics/":                          {CategoryNews, ""},
+	"Religion & Spirituality/Other":             {CategoryReligionAndSpirituality, CategoryReligionAndSpiritualityReligion},
+	"Science & Medicine/":                       {CategoryScience, ""},
+	"Science & Medicine/Medicine":               {CategoryHealthAndFitness, CategoryHealthAndFitnessMedicine},
+	"Science & Medicine/Natural Sciences":       {CategoryScience, CategoryScienceNaturalSciences},
+	"Science & Medicine/Social Sciences":        {CategoryScience, CategoryScienceSocialSciences},
+	"Society & Culture/History":                 {CategoryHistory, ""},
+	"Sports & Recreation/":                      {CategorySports, ""},
+	"Sports & Recreation/Amateur":               {CategorySports, ""},
+	"Sports & Recreation/College & High School": {CategorySports, ""},
+	"Sports & Recreation/Outdoor":               {CategorySports, CategorySportsWilderness},
+	"Sports & Recreation/Professional":          {CategorySports, ""},
+	"Technology/Gadgets":                        {CategoryTechnology, ""},
+	"Technology/Podcasting":                     {CategoryTechnology, ""},
+	"Technology/Software How-To":                {CategoryTechnology, ""},
+	"Technology/Tech News":                      {CategoryNews, CategoryNewsTechNews},
+}
+
+// AddAppleCategory adds the category to the Podcast, like AddCategory,
+// after confirming the category and subcategories exist in the current
+// Apple Podcasts taxonomy.  See ModernAppleCategory to convert categories
+// that Apple retired in 2019.
+func (p *Podcast) AddAppleCategory(category AppleCategory,
+	subCategories []AppleCategory) error {
+	subs := make([]string, 0, len(subCategories))
+	for _, s := range subCategories {
+		subs = append(subs, string(s))
+	}
+	if err := ValidateAppleCategory(string(category), subs); err != nil {
+		return err
+	}
+	p.AddCategory(string(category), subs)
+	return nil
+}
+
+// ValidateAppleCategory returns an error if the category is not a
+// top-level Apple Podcasts category, or if any of the subCategories does
+// not belong to it.
+func ValidateAppleCategory(category string, subCategories []string) error {
+	allowed, ok := appleCategories[AppleCategory(category)]
+	if !ok {
+		return errors.New(category + ": not an Apple Podcasts category")
+	}
+	for _, s := range subCategories {
+		if !containsAppleCategory(allowed, AppleCategory(s)) {
+			return errors.New(category + ": " + s +
+				" is not an Apple Podcasts subcategory of " + category)
+		}
+	}
+	return nil
+}
+
+// ModernAppleCategory returns the current Apple Podcasts category and
+// subcategory for the category and optional subCategory, mapping the
+// legacy names retired in 2019 (such as "Games & Hobbies" or
+// "Science & Medicine") to their replacements.  The modern subcategory is
+// empty when there is no direct replacement.
+//
+// Categories that are already valid are returned as-is, and ok is false
+// when no mapping exists.
+func ModernAppleCategory(category, subCategory string) (
+	modern, modernSub AppleCategory, ok bool) {
+	subs := []string{}
+	if len(subCategory) > 0 {
+		subs = append(subs, subCategory)
+	}
+	if ValidateAppleCategory(category, subs) == nil {
+		return AppleCategory(category), AppleCategory(subCategory), true
+	}
+	m, ok := legacyAppleCategories[category+"/"+subCategory]
+	if !ok {
+		// fallback to the legacy parent category
+		m, ok = legacyAppleCategories[category+"/"]
+	}
+	if !ok && ValidateAppleCategory(category, nil) == nil {
+		m, ok = [2]AppleCategory{AppleCategory(category), ""}, true
+	}
+	return m[0], m[1], ok
+}
+
+func containsAppleCategory(list []AppleCategory, c AppleCategory) bool {
+	for _, l := range list {
+		if l == c {
+			return true
+		}
+	}
+	return false
+}
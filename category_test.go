@@ -0,0 +1,89 @@
+package podcast_test
+
+import (
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAppleCategoryInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddAppleCategory("Games & Hobbies", nil)
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an Apple Podcasts category")
+	assert.Len(t, p.ICategories, 0)
+	assert.Len(t, p.Category, 0)
+}
+
+func TestAddAppleCategoryInvalidSubCategory(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddAppleCategory(podcast.CategoryTechnology,
+		[]podcast.AppleCategory{"Podcasting"})
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Podcasting is not an Apple Podcasts subcategory")
+	assert.Len(t, p.ICategories, 0)
+}
+
+func TestValidateAppleCategory(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, podcast.ValidateAppleCategory("Sports", []string{"Running"}))
+	assert.NoError(t, podcast.ValidateAppleCategory("True Crime", nil))
+	assert.Error(t, podcast.ValidateAppleCategory("sports", nil))
+	assert.Error(t, podcast.ValidateAppleCategory("Sports", []string{"Drama"}))
+}
+
+func TestModernAppleCategoryUnknown(t *testing.T) {
+	t.Parallel()
+
+	// act
+	c, sub, ok := podcast.ModernAppleCategory("Knitting", "Socks")
+
+	// assert
+	assert.False(t, ok)
+	assert.Empty(t, c)
+	assert.Empty(t, sub)
+}
+
+func TestModernAppleCategoryLegacyParentFallback(t *testing.T) {
+	t.Parallel()
+
+	// act
+	c, sub, ok := podcast.ModernAppleCategory("Sports & Recreation", "Curling")
+
+	// assert
+	assert.True(t, ok)
+	assert.EqualValues(t, podcast.CategorySports, c)
+	assert.Empty(t, sub)
+}
+
+func TestModernAppleCategoryValidParentUnknownSub(t *testing.T) {
+	t.Parallel()
+
+	// act
+	c, sub, ok := podcast.ModernAppleCategory("Technology", "Gadgets")
+	c2, sub2, ok2 := podcast.ModernAppleCategory("Arts", "Knitting")
+
+	// assert
+	assert.True(t, ok)
+	assert.EqualValues(t, podcast.CategoryTechnology, c)
+	assert.Empty(t, sub)
+	assert.True(t, ok2)
+	assert.EqualValues(t, podcast.CategoryArts, c2)
+	assert.Empty(t, sub2)
+}
@@ -0,0 +1,131 @@
+package podcast
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://github.com/Podcastindex-org/podcast-namespace/blob/main/chapters/jsonChapters.md
+//
+
+const (
+	// ChaptersType is the MIME type of the JSON chapters file.
+	ChaptersType = "application/json+chapters"
+
+	chaptersVersion = "1.2.0"
+)
+
+// Chapters is the JSON chapters file of an episode, which is linked to
+// the Item with the podcast:chapters tag.
+type Chapters struct {
+	Version     string     `json:"version"`
+	Author      string     `json:"author,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	PodcastName string     `json:"podcastName,omitempty"`
+	Chapters    []*Chapter `json:"chapters"`
+}
+
+// Chapter is a single chapter of the episode starting StartTime seconds
+// into the audio.
+//
+// A TOC of false hides the chapter from the table of contents, such as
+// when it only changes the artwork.
+type Chapter struct {
+	StartTime float64          `json:"startTime"`
+	EndTime   float64          `json:"endTime,omitempty"`
+	Title     string           `json:"title,omitempty"`
+	Img       string           `json:"img,omitempty"`
+	URL       string           `json:"url,omitempty"`
+	TOC       *bool            `json:"toc,omitempty"`
+	Location  *ChapterLocation `json:"location,omitempty"`
+}
+
+// ChapterLocation is the place a Chapter is about, with Geo as a
+// "geo:latitude,longitude" URI.
+type ChapterLocation struct {
+	Name string `json:"name"`
+	Geo  string `json:"geo"`
+	OSM  string `json:"osm,omitempty"`
+}
+
+// AddChapter adds a Chapter starting at startTime seconds.  Calling this
+// method multiple times will APPEND the chapter to the existing list.
+//
+// The startTime must not be negative.
+func (c *Chapters) AddChapter(startTime float64, title string) error {
+	if startTime < 0 {
+		return errors.New(title + ": Chapter.StartTime must not be negative")
+	}
+	c.Chapters = append(c.Chapters, &Chapter{StartTime: startTime, Title: title})
+	return nil
+}
+
+// Encode writes the JSON chapters file to the io.Writer stream.
+//
+// The Version defaults to "1.2.0" when not set.
+func (c *Chapters) Encode(w io.Writer) error {
+	out := *c
+	if len(out.Version) == 0 {
+		out.Version = chaptersVersion
+	}
+	if out.Chapters == nil {
+		out.Chapters = []*Chapter{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	if err := e.Encode(&out); err != nil {
+		return errors.Wrap(err, "podcast.Chapters.Encode: e.Encode returned error")
+	}
+	return nil
+}
+
+// AddJSONChapters adds the podcast:chapters link to the Item for the JSON
+// chapters file, written with Chapters.Encode, hosted at url.
+func (i *Item) AddJSONChapters(url string) error {
+	return i.AddChapters(url, ChaptersType)
+}
+
+// chapterLine matches a "HH:MM:SS Title" line, also allowing "MM:SS",
+// brackets around the time and a dash before the title.
+var chapterLine = regexp.MustCompile(`^[\[(]?((?:\d+:)?\d{1,2}:\d{2})[\])]?\s*(?:[-–—:]\s*)?(.+)$`)
+
+// ParseChapters reads chapters from a text list with one chapter per line,
+// as hosts often write them in show notes:
+//
+//   00:00 Intro
+//   02:15 - News of the week
+//   1:05:30 Listener questions
+//
+// Lines without a leading timestamp are ignored.  The chapters must be
+// in ascending order and at least one is required.
+func ParseChapters(r io.Reader) (*Chapters, error) {
+	c := &Chapters{Version: chaptersVersion}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		m := chapterLine.FindStringSubmatch(strings.TrimSpace(s.Text()))
+		if m == nil {
+			continue
+		}
+		start := float64(parseDurationSeconds(m[1]))
+		if n := len(c.Chapters); n > 0 && start < c.Chapters[n-1].StartTime {
+			return nil, errors.New("podcast.ParseChapters: " + m[1] + " is out of order")
+		}
+		c.Chapters = append(c.Chapters, &Chapter{
+			StartTime: start,
+			Title:     strings.TrimSpace(m[2]),
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "podcast.ParseChapters: s.Scan returned error")
+	}
+	if len(c.Chapters) == 0 {
+		return nil, errors.New("podcast.ParseChapters: no chapters found")
+	}
+	return c, nil
+}
@@ -0,0 +1,84 @@
+package podcast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaptersAddChapterNegative(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	c := podcast.Chapters{}
+
+	// act
+	err := c.AddChapter(-1, "Intro")
+
+	// assert
+	assert.Error(t, err)
+	assert.Len(t, c.Chapters, 0)
+}
+
+func TestChaptersEncodeError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	c := podcast.Chapters{}
+
+	// act
+	err := c.Encode(&errWriter{})
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestParseChaptersFormats(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	notes := "(00:00) Intro\n[1:02] Topic: one\n  01:02:03 — Outro  \nnot a chapter 00:05"
+
+	// act
+	c, err := podcast.ParseChapters(strings.NewReader(notes))
+
+	// assert
+	if assert.NoError(t, err) && assert.Len(t, c.Chapters, 3) {
+		assert.EqualValues(t, 0, c.Chapters[0].StartTime)
+		assert.EqualValues(t, "Intro", c.Chapters[0].Title)
+		assert.EqualValues(t, 62, c.Chapters[1].StartTime)
+		assert.EqualValues(t, "Topic: one", c.Chapters[1].Title)
+		assert.EqualValues(t, 3723, c.Chapters[2].StartTime)
+		assert.EqualValues(t, "Outro", c.Chapters[2].Title)
+	}
+}
+
+func TestParseChaptersNone(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	notes := "just some show notes"
+
+	// act
+	c, err := podcast.ParseChapters(strings.NewReader(notes))
+
+	// assert
+	assert.Nil(t, c)
+	assert.EqualError(t, err, "podcast.ParseChapters: no chapters found")
+}
+
+func TestParseChaptersOutOfOrder(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	notes := "05:00 Second\n01:00 First"
+
+	// act
+	c, err := podcast.ParseChapters(strings.NewReader(notes))
+
+	// assert
+	assert.Nil(t, c)
+	assert.EqualError(t, err, "podcast.ParseChapters: 01:00 is out of order")
+}
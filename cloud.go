@@ -0,0 +1,91 @@
+package podcast
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://www.rssboard.org/rsscloud-interface
+//
+
+// Cloud represents the rssCloud web service that aggregators register
+// with to be notified of updates to the feed.
+type Cloud struct {
+	XMLName           xml.Name `xml:"cloud"`
+	Domain            string   `xml:"domain,attr"`
+	Port              int      `xml:"port,attr"`
+	Path              string   `xml:"path,attr"`
+	RegisterProcedure string   `xml:"registerProcedure,attr"`
+	Protocol          string   `xml:"protocol,attr"`
+}
+
+// AddCloud adds the rssCloud web service at the domain, port and path
+// that aggregators register with by calling the registerProcedure using
+// the protocol, which is one of "xml-rpc", "soap" or "http-post".
+//
+// An error is returned when the domain or path is missing, the port is
+// out of range or the protocol is unknown.
+func (p *Podcast) AddCloud(domain string, port int, path, registerProcedure, protocol string) error {
+	c := &Cloud{
+		Domain:            domain,
+		Port:              port,
+		Path:              path,
+		RegisterProcedure: registerProcedure,
+		Protocol:          protocol,
+	}
+	if err := validateCloud(c); err != nil {
+		return err
+	}
+	p.CloudFormatted = c
+	return nil
+}
+
+func validateCloud(c *Cloud) error {
+	if len(c.Domain) == 0 || len(c.Path) == 0 {
+		return errors.New("Cloud.Domain and Cloud.Path are required")
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		return errors.New("Cloud.Port " + strconv.Itoa(c.Port) +
+			" must be from 1 to 65535")
+	}
+	switch c.Protocol {
+	case "xml-rpc", "soap", "http-post":
+	default:
+		return errors.New("Cloud.Protocol " + c.Protocol +
+			" must be xml-rpc, soap or http-post")
+	}
+	return nil
+}
+
+// parseCloud returns the Cloud of the legacy Cloud string, a URL such as
+// "http://rpc.example.com/notify" that is registered with using
+// http-post, or nil when it is not a URL.
+func parseCloud(s string) *Cloud {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || len(u.Hostname()) == 0 {
+		return nil
+	}
+	c := &Cloud{
+		Domain:   u.Hostname(),
+		Port:     80,
+		Path:     u.EscapedPath(),
+		Protocol: "http-post",
+	}
+	if u.Scheme == "https" {
+		c.Port = 443
+	}
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		c.Port = port
+	}
+	if len(c.Path) == 0 {
+		c.Path = "/"
+	}
+	if validateCloud(c) != nil {
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/eduncan911/podcast/internal/id3"
+	"github.com/pkg/errors"
+)
+
+const buildArgs = `[-config file] [-o file] dir
+
+Build writes the feed of the audio and video files in dir, newest first
+by their modification time, which is the episode's publication date.
+
+The title and description of each episode are read from its sidecar
+file, named as the media file with a .txt extension, whose first line is
+the title and the rest the description.  Otherwise they are the title
+and comment of the ID3 tag, or else the file name.
+
+The YAML config sets the channel with these keys:
+
+  title, link, description  required
+  media_url                 the URL of dir, defaults to link
+  feed_url                  the URL of the feed itself
+  language, copyright, summary, image
+  category, subcategories   an iTunes category and its subcategories
+  explicit                  true or false
+  type                      episodic, or serial to number the episodes
+  author, owner             maps of name and email
+`
+
+func runBuild(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("build", buildArgs, stderr)
+	configPath := fs.String("config", "", "the show config (default dir/podcast.yaml)")
+	out := fs.String("o", "", "the feed file, or - for stdout (default dir/feed.xml)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	dir := fs.Arg(0)
+	if len(*configPath) == 0 {
+		*configPath = filepath.Join(dir, "podcast.yaml")
+	}
+	if len(*out) == 0 {
+		*out = filepath.Join(dir, "feed.xml")
+	}
+
+	cfg, err := readConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	p, err := build(dir, cfg)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	if err := p.Encode(&b); err != nil {
+		return err
+	}
+	if *out == "-" {
+		_, err = stdout.Write(b.Bytes())
+		return err
+	}
+	return ioutil.WriteFile(*out, b.Bytes(), 0644)
+}
+
+// episode is a media file of the directory.
+type episode struct {
+	name     string
+	path     string
+	modified time.Time
+}
+
+// build returns the Podcast of the media files in dir and the config.
+func build(dir string, cfg *config) (*podcast.Podcast, error) {
+	for _, required := range []struct{ key, value string }{
+		{"title", cfg.Title},
+		{"link", cfg.Link},
+		{"description", cfg.Description},
+	} {
+		if len(required.value) == 0 {
+			return nil, errors.New("config: " + required.key + " is required")
+		}
+	}
+	mediaURL := cfg.MediaURL
+	if len(mediaURL) == 0 {
+		mediaURL = cfg.Link
+	}
+	if !strings.HasSuffix(mediaURL, "/") {
+		mediaURL += "/"
+	}
+
+	episodes, err := readEpisodes(dir)
+	if err != nil {
+		return nil, err
+	}
+	var updated *time.Time
+	if len(episodes) > 0 {
+		updated = &episodes[0].modified
+	}
+	p := podcast.New(cfg.Title, cfg.Link, cfg.Description, updated, updated)
+	p.Language = cfg.Language
+	if len(p.Language) == 0 {
+		p.Language = "en-us"
+	}
+	p.Copyright = cfg.Copyright
+	p.AddSummary(cfg.Summary)
+	p.AddAtomLink(cfg.FeedURL)
+	p.AddImage(cfg.Image)
+	p.AddAuthor(cfg.Author.Name, cfg.Author.Email)
+	if len(cfg.Owner.Email) > 0 {
+		p.IOwner = &podcast.Author{
+			Name:  cfg.Owner.Name,
+			Email: cfg.Owner.Email,
+		}
+	}
+	p.AddCategory(cfg.Category, cfg.Subcategories)
+	if cfg.Explicit != nil {
+		p.IExplicit = "false"
+		if *cfg.Explicit {
+			p.IExplicit = "true"
+		}
+	}
+	switch t := cfg.Type; t {
+	case "", "episodic":
+	case "serial":
+		p.AddShowType(podcast.ShowSerial)
+	default:
+		return nil, errors.New("config: type " + t + " must be episodic or serial")
+	}
+
+	for n, e := range episodes {
+		title, description, err := e.metadata()
+		if err != nil {
+			return nil, errors.Wrap(err, e.name)
+		}
+		i := podcast.Item{
+			Title:       title,
+			Description: description,
+		}
+		i.AddPubDate(&e.modified)
+		if p.IType == podcast.ShowSerial.String() {
+			i.AddSeasonEpisode(0, len(episodes)-n) // numbered from the oldest
+		}
+		if err := i.AddEnclosureFromFile(mediaURL+url.PathEscape(e.name), e.path); err != nil {
+			return nil, err
+		}
+		if _, err := p.AddItem(i); err != nil {
+			return nil, errors.Wrap(err, e.name)
+		}
+	}
+	return &p, nil
+}
+
+// readEpisodes returns the audio and video files of dir, newest first.
+func readEpisodes(dir string) ([]*episode, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var episodes []*episode
+	for _, fi := range files {
+		et, ok := podcast.EnclosureTypeByExtension(filepath.Ext(fi.Name()))
+		if fi.IsDir() || !ok || !isMedia(et) {
+			continue
+		}
+		episodes = append(episodes, &episode{
+			name:     fi.Name(),
+			path:     filepath.Join(dir, fi.Name()),
+			modified: fi.ModTime(),
+		})
+	}
+	sort.Stable(byModified(episodes))
+	return episodes, nil
+}
+
+// isMedia reports whether the EnclosureType is an audio or video format.
+func isMedia(et podcast.EnclosureType) bool {
+	mime := et.String()
+	return strings.HasPrefix(mime, "audio/") || strings.HasPrefix(mime, "video/")
+}
+
+type byModified []*episode
+
+func (s byModified) Len() int           { return len(s) }
+func (s byModified) Swap(a, b int)      { s[a], s[b] = s[b], s[a] }
+func (s byModified) Less(a, b int) bool { return s[a].modified.After(s[b].modified) }
+
+// metadata returns the title and description of the episode from its
+// sidecar file, or else its ID3 tag, or else its file name.
+func (e *episode) metadata() (string, string, error) {
+	var title, description string
+	base := strings.TrimSuffix(e.path, filepath.Ext(e.path))
+	if b, err := ioutil.ReadFile(base + ".txt"); err == nil {
+		lines := strings.SplitN(strings.TrimSpace(string(b)), "\n", 2)
+		title = strings.TrimSpace(lines[0])
+		if len(lines) > 1 {
+			description = strings.TrimSpace(lines[1])
+		}
+	} else if !os.IsNotExist(err) {
+		return "", "", err
+	}
+	if len(title) > 0 {
+		return title, orDefault(description, title), nil
+	}
+
+	f, err := os.Open(e.path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+	tags, err := id3.Read(f)
+	if err != nil {
+		return "", "", err
+	}
+	title = orDefault(tags.Title, strings.TrimSuffix(e.name, filepath.Ext(e.name)))
+	return title, orDefault(description, orDefault(tags.Comment, title)), nil
+}
+
+func orDefault(s, def string) string {
+	if len(s) == 0 {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+// id3Frame returns an ID3v2.3 frame with the body.
+func id3Frame(id string, body []byte) []byte {
+	f := append([]byte(id), 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(f[4:8], uint32(len(body)))
+	return append(f, body...)
+}
+
+// mp3 returns an MP3 file of 38 MPEG-1 layer III frames, about 1 second,
+// with an ID3v2.3 tag of the frames.
+func mp3(frames ...[]byte) []byte {
+	var tag []byte
+	for _, f := range frames {
+		tag = append(tag, f...)
+	}
+	size := len(tag)
+	b := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	b = append(b, tag...)
+	frame := make([]byte, 417) // 128 kbit/s at 44.1 kHz
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	for n := 0; n < 38; n++ {
+		b = append(b, frame...)
+	}
+	return b
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte, modified time.Time) {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "podcast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+var (
+	day1 = time.Date(2017, 2, 1, 8, 0, 0, 0, time.UTC)
+	day2 = day1.AddDate(0, 0, 1)
+	day3 = day1.AddDate(0, 0, 2)
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeFile(t, dir, "podcast.yaml", []byte(`
+title: title
+link: http://example.com/
+description: description
+media_url: http://cdn.example.com/media
+feed_url: http://example.com/feed.xml
+category: Technology
+explicit: false
+type: serial
+author:
+  name: Jane Doe
+  email: me@janedoe.com
+`), day1)
+	writeFile(t, dir, "episode 1.mp3", mp3(id3Frame("TIT2", append([]byte{3}, "Tagged"...))), day1)
+	writeFile(t, dir, "episode 1.txt", []byte("\n"), day1)
+	writeFile(t, dir, "episode2.mp3", mp3(), day3)
+	writeFile(t, dir, "episode2.txt", []byte("Sidecar\n\nThe show notes.\n"), day3)
+	writeFile(t, dir, "episode3.mp3", mp3(), day2)
+	writeFile(t, dir, "cover.jpg", []byte("not media"), day3)
+
+	// act
+	var stderr bytes.Buffer
+	code := run([]string{"build", dir}, ioutil.Discard, &stderr)
+
+	// assert
+	assert.Equal(t, 0, code, stderr.String())
+	f, err := os.Open(filepath.Join(dir, "feed.xml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+	p, err := podcast.Decode(f)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "title", p.Title)
+	assert.Equal(t, "me@janedoe.com (Jane Doe)", p.IAuthor)
+	assert.Equal(t, "serial", p.IType)
+	assert.Equal(t, "false", p.IExplicit)
+	assert.Equal(t, "http://example.com/feed.xml", p.AtomLink.HREF)
+	if assert.Len(t, p.Items, 3) {
+		assert.Equal(t, "Sidecar", p.Items[0].Title)
+		assert.Equal(t, "The show notes.", p.Items[0].Description)
+		assert.Equal(t, "episode3", p.Items[1].Title)
+		assert.Equal(t, "episode3", p.Items[1].Description)
+		assert.Equal(t, "Tagged", p.Items[2].Title)
+		assert.Equal(t, "Tagged", p.Items[2].Description)
+		assert.Equal(t, "http://cdn.example.com/media/episode%201.mp3", p.Items[2].Enclosure.URL)
+		assert.EqualValues(t, 38*417+10+len(id3Frame("TIT2", []byte{3, 'T', 'a', 'g', 'g', 'e', 'd'})),
+			p.Items[2].Enclosure.Length)
+		assert.Equal(t, "0:01", p.Items[2].IDuration)
+		assert.True(t, day1.Equal(*p.Items[2].PubDate))
+		assert.Equal(t, 1, p.Items[2].IEpisode)
+		assert.Equal(t, 3, p.Items[0].IEpisode)
+	}
+	assert.Equal(t, day3.Format(time.RFC1123Z), p.PubDate)
+}
+
+func TestBuildStdout(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeFile(t, dir, "show.yaml", []byte("title: t\nlink: l\ndescription: d\n"), day1)
+	var stdout bytes.Buffer
+
+	// act
+	code := run([]string{"build", "-config", filepath.Join(dir, "show.yaml"), "-o", "-", dir},
+		&stdout, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "<title>t</title>")
+	_, err := os.Stat(filepath.Join(dir, "feed.xml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBuildErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeFile(t, dir, "podcast.yaml", []byte("title: t\nlink: l\n"), day1)
+	var stderr bytes.Buffer
+
+	// act
+	missing := run([]string{"build", dir}, ioutil.Discard, &stderr)
+	noDir := run([]string{"build"}, ioutil.Discard, ioutil.Discard)
+	unknown := run([]string{"unknown"}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 1, missing)
+	assert.Equal(t, "podcast build: config: description is required\n", stderr.String())
+	assert.Equal(t, 2, noDir)
+	assert.Equal(t, 2, unknown)
+}
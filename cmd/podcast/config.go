@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// config is the show config of the build command, read from YAML:
+//
+//   title: Go Time
+//   link: https://example.com/
+//   description: A weekly show about Go
+//   media_url: https://cdn.example.com/episodes/
+//   category: Technology
+//   subcategories: [Software How-To]
+//   author:
+//     name: Jane Doe
+//     email: jane@example.com
+//
+// Unknown keys are errors, so that a misspelled key is not ignored.
+type config struct {
+	Title         string
+	Link          string
+	Description   string
+	MediaURL      string `yaml:"media_url"`
+	FeedURL       string `yaml:"feed_url"`
+	Language      string
+	Copyright     string
+	Summary       string
+	Image         string
+	Category      string
+	Subcategories []string
+	Explicit      *bool
+	Type          string
+	Author        configPerson
+	Owner         configPerson
+}
+
+// configPerson is the author or owner of the show.
+type configPerson struct {
+	Name  string
+	Email string
+}
+
+// readConfig reads the config file at path.
+func readConfig(path string) (*config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := parseConfig(f)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return c, nil
+}
+
+// parseConfig parses the YAML of the config.
+func parseConfig(r io.Reader) (*config, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &config{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
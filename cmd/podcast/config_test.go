@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	in := `# show config
+title: 'Go "Time"' # trailing comment
+link: https://example.com/#home
+explicit: false
+subcategories: [Software How-To, Tech News]
+author:
+  name: Jane Doe
+`
+
+	// act
+	c, err := parseConfig(strings.NewReader(in))
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, `Go "Time"`, c.Title)
+	assert.Equal(t, "https://example.com/#home", c.Link)
+	if assert.NotNil(t, c.Explicit) {
+		assert.False(t, *c.Explicit)
+	}
+	assert.EqualValues(t, []string{"Software How-To", "Tech News"}, c.Subcategories)
+	assert.Equal(t, "Jane Doe", c.Author.Name)
+	assert.Equal(t, "", c.Owner.Email)
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"title",
+		"title: [a",
+		"explicit: maybe",
+		"titel: misspelled",
+		"title: a\ntitle: b",
+		"author: Jane Doe",
+	}
+	for _, in := range tests {
+		// act
+		_, err := parseConfig(strings.NewReader(in))
+
+		// assert
+		assert.Error(t, err, in)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/eduncan911/podcast"
+	"github.com/pkg/errors"
+)
+
+const diffArgs = `[-format text|json] old new
+
+Diff prints the changes from the old to the new RSS feed, each a file,
+an http or https URL, or stdin for -: the modified channel fields, and
+the added, removed and modified episodes matched by their GUID.
+
+The exit status is 0 when the feeds are the same, 3 when they differ and
+4 when an episode's GUID changed, which makes apps download it again.
+`
+
+// Exit statuses of the diff command.
+const (
+	exitChanged     exitStatus = 3
+	exitGUIDChanged exitStatus = 4
+)
+
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("diff", diffArgs, stderr)
+	format := fs.String("format", "text", "the output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if *format != "text" && *format != "json" {
+		return errors.New("unknown format " + *format)
+	}
+	var feeds [2]*podcast.Podcast
+	for n, name := range fs.Args() {
+		data, err := readFeed(name)
+		if err != nil {
+			return err
+		}
+		if feeds[n], err = podcast.Decode(bytes.NewReader(data)); err != nil {
+			return errors.Wrap(err, name)
+		}
+	}
+
+	changes := podcast.Diff(feeds[0], feeds[1])
+	if *format == "json" {
+		if err := writeChanges(stdout, changes); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range changes {
+			if _, err := fmt.Fprintln(stdout, c); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, c := range changes {
+		if c.Type == podcast.ChangeGUID {
+			return exitGUIDChanged
+		}
+	}
+	if len(changes) > 0 {
+		return exitChanged
+	}
+	return nil
+}
+
+type jsonChange struct {
+	Type  string `json:"type"`
+	GUID  string `json:"guid,omitempty"`
+	Title string `json:"title,omitempty"`
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+func writeChanges(w io.Writer, changes []podcast.Change) error {
+	out := []*jsonChange{}
+	for _, c := range changes {
+		out = append(out, &jsonChange{
+			Type:  c.Type.String(),
+			GUID:  c.GUID,
+			Title: c.Title,
+			Field: c.Field,
+			Old:   c.Old,
+			New:   c.New,
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(out)
+}
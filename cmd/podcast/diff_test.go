@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	before, cleanupBefore := writeFeed(t, lintFeed)
+	defer cleanupBefore()
+	feed := strings.Replace(lintFeed, "<title>title</title>", "<title>new title</title>", 1)
+	feed = strings.Replace(feed, `<guid isPermaLink="false">1</guid>`, "<guid>http://example.com/1</guid>", 1)
+	after, cleanupAfter := writeFeed(t, feed)
+	defer cleanupAfter()
+	same, cleanupSame := writeFeed(t, lintFeed)
+	defer cleanupSame()
+	var stdout bytes.Buffer
+
+	// act
+	changed := run([]string{"diff", before, after}, &stdout, ioutil.Discard)
+	unchanged := run([]string{"diff", before, same}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 4, changed)
+	assert.Equal(t, `modified Title: "title" => "new title"
+guid of item "t" changed: "1" => "http://example.com/1"
+`, stdout.String())
+	assert.Equal(t, 0, unchanged)
+}
+
+func TestDiffJSON(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	before, cleanupBefore := writeFeed(t, lintFeed)
+	defer cleanupBefore()
+	after, cleanupAfter := writeFeed(t, strings.Replace(lintFeed, "<description>d</description>",
+		"<description>notes</description>", 1))
+	defer cleanupAfter()
+	var stdout bytes.Buffer
+
+	// act
+	code := run([]string{"diff", "-format", "json", before, after}, &stdout, ioutil.Discard)
+	var out []*jsonChange
+	err := json.Unmarshal(stdout.Bytes(), &out)
+
+	// assert
+	assert.Equal(t, 3, code)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*jsonChange{{
+		Type:  "modified",
+		GUID:  "1",
+		Title: "t",
+		Field: "Description",
+		Old:   "d",
+		New:   "notes",
+	}}, out)
+}
+
+func TestDiffErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path, cleanup := writeFeed(t, lintFeed)
+	defer cleanup()
+	invalid, cleanupInvalid := writeFeed(t, "<rss></rss>")
+	defer cleanupInvalid()
+	var stderr bytes.Buffer
+
+	// act
+	decode := run([]string{"diff", path, invalid}, ioutil.Discard, &stderr)
+	usage := run([]string{"diff", path}, ioutil.Discard, ioutil.Discard)
+	format := run([]string{"diff", "-format", "sarif", path, path}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 1, decode)
+	assert.Equal(t, "podcast diff: "+invalid+": podcast.Decode: channel is required\n", stderr.String())
+	assert.Equal(t, 2, usage)
+	assert.Equal(t, 1, format)
+}
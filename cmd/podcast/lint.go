@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/eduncan911/podcast"
+	"github.com/pkg/errors"
+)
+
+const lintArgs = `[-profile list] [-format text|json|sarif] [-fail severity] file-or-url
+
+Lint decodes the RSS feed in the file, at the http or https URL, or on
+stdin for -, and checks it against the RSS 2.0 rules, including the Item
+requirements of AddItem, and the rules of the profiles.
+
+The exit status is 3 when the worst finding is a warning and 4 when it
+is an error, unless below the -fail severity, and 1 when the feed cannot
+be read.
+`
+
+// Exit statuses of the lint command by the worst Severity found.
+const (
+	exitWarning exitStatus = 3
+	exitError   exitStatus = 4
+)
+
+var lintProfiles = []podcast.Profile{
+	podcast.ProfileApple,
+	podcast.ProfileSpotify,
+	podcast.ProfilePodcasting20,
+}
+
+func runLint(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("lint", lintArgs, stderr)
+	profileList := fs.String("profile", "apple,spotify,podcasting2.0",
+		"the comma separated profiles to check, besides rss")
+	format := fs.String("format", "text", "the output format: text, json or sarif")
+	fail := fs.String("fail", "warning", "the lowest severity failing the lint: warning, error or none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	profiles, err := parseProfiles(*profileList)
+	if err != nil {
+		return err
+	}
+	failAt, err := parseSeverity(*fail)
+	if err != nil {
+		return err
+	}
+	write, ok := lintFormats[*format]
+	if !ok {
+		return errors.New("unknown format " + *format)
+	}
+
+	name := fs.Arg(0)
+	data, err := readFeed(name)
+	if err != nil {
+		return err
+	}
+	p, err := podcast.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	l := &lint{
+		name:   name,
+		report: p.Validate(profiles...),
+		lines:  feedLines(data),
+	}
+	if err := write(stdout, l); err != nil {
+		return err
+	}
+
+	worst := podcast.Severity(-1)
+	for _, f := range l.report.Findings {
+		if f.Severity > worst {
+			worst = f.Severity
+		}
+	}
+	switch {
+	case worst < failAt:
+		return nil
+	case worst == podcast.SeverityWarning:
+		return exitWarning
+	}
+	return exitError
+}
+
+// lint is the Report of the feed named name.
+type lint struct {
+	name   string
+	report *podcast.Report
+	lines  *lines
+}
+
+// readFeed returns the feed at the http or https URL, in the file, or on
+// stdin for -.
+var readFeed = func(name string) ([]byte, error) {
+	switch {
+	case name == "-":
+		return ioutil.ReadAll(os.Stdin)
+	case strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://"):
+		resp, err := http.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New(name + " returned " + resp.Status)
+		}
+		return ioutil.ReadAll(resp.Body)
+	}
+	return ioutil.ReadFile(name)
+}
+
+func parseProfiles(list string) ([]podcast.Profile, error) {
+	var profiles []podcast.Profile
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		found := false
+		for _, p := range append([]podcast.Profile{podcast.ProfileRSS}, lintProfiles...) {
+			if strings.EqualFold(name, p.String()) {
+				profiles, found = append(profiles, p), true
+			}
+		}
+		if !found {
+			return nil, errors.New("unknown profile " + name)
+		}
+	}
+	return profiles, nil
+}
+
+// parseSeverity returns the Severity failing the lint, which is at least
+// SeverityWarning as there is no exit status for info findings.
+func parseSeverity(name string) (podcast.Severity, error) {
+	for s := podcast.SeverityWarning; s <= podcast.SeverityError; s++ {
+		if name == s.String() {
+			return s, nil
+		}
+	}
+	if name == "none" {
+		return podcast.SeverityError + 1, nil
+	}
+	return 0, errors.New("unknown severity " + name)
+}
+
+// lines are the line numbers of the channel and item elements of a feed,
+// which the Findings are reported at.
+type lines struct {
+	channel int
+	items   []int
+}
+
+// feedLines returns the lines of the channel and items of the feed.
+func feedLines(data []byte) *lines {
+	l := &lines{}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	depth := 0
+	for {
+		offset := d.InputOffset()
+		t, err := d.RawToken()
+		if err != nil {
+			return l
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			depth++
+			line := bytes.Count(data[:offset], []byte("\n")) + 1
+			switch {
+			case depth == 2 && t.Name.Local == "channel":
+				l.channel = line
+			case depth == 3 && t.Name.Local == "item":
+				l.items = append(l.items, line)
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+var itemPath = regexp.MustCompile(`^Items\[(\d+)\]`)
+
+// line returns the line of the element of the Finding's Path, the item
+// or else the channel, or 0 when unknown.
+func (l *lines) line(f podcast.Finding) int {
+	if m := itemPath.FindStringSubmatch(f.Path); m != nil {
+		if n, _ := strconv.Atoi(m[1]); n < len(l.items) {
+			return l.items[n]
+		}
+	}
+	return l.channel
+}
+
+var lintFormats = map[string]func(w io.Writer, l *lint) error{
+	"text":  writeText,
+	"json":  writeJSON,
+	"sarif": writeSARIF,
+}
+
+// writeText writes each Finding as "name:line: severity path: message
+// (rule)".
+func writeText(w io.Writer, l *lint) error {
+	for _, f := range l.report.Findings {
+		if _, err := fmt.Fprintf(w, "%s:%d: %s\n", l.name, l.lines.line(f), f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonLint struct {
+	File     string         `json:"file"`
+	Profiles []string       `json:"profiles"`
+	Findings []*jsonFinding `json:"findings"`
+}
+
+type jsonFinding struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func writeJSON(w io.Writer, l *lint) error {
+	out := &jsonLint{File: l.name, Findings: []*jsonFinding{}}
+	for _, p := range l.report.Profiles {
+		out.Profiles = append(out.Profiles, p.String())
+	}
+	for _, f := range l.report.Findings {
+		out.Findings = append(out.Findings, &jsonFinding{
+			Severity: f.Severity.String(),
+			Path:     f.Path,
+			Rule:     f.Rule,
+			Message:  f.Message,
+			Line:     l.lines.line(f),
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(out)
+}
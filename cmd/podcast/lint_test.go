@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>title</title>
+    <link>http://example.com/</link>
+    <description>description</description>
+    <language>en-us</language>
+    <item>
+      <guid isPermaLink="false">1</guid>
+      <title>t</title>
+      <description>d</description>
+      <link>http://example.com/1</link>
+    </item>
+    <item>
+      <guid isPermaLink="false">2</guid>
+      <title>t</title>
+      <description>d</description>
+    </item>
+  </channel>
+</rss>
+`
+
+func writeFeed(t *testing.T, feed string) (string, func()) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "feed.xml")
+	if err := ioutil.WriteFile(path, []byte(feed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLintText(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path, cleanup := writeFeed(t, lintFeed)
+	defer cleanup()
+	var stdout bytes.Buffer
+
+	// act
+	code := run([]string{"lint", "-profile", "rss", path}, &stdout, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 4, code)
+	assert.Equal(t, path+":14: error Items[1].Link: t: Link is required when not using Enclosure (rss-item-link)\n",
+		stdout.String())
+}
+
+func TestLintFailSeverity(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path, cleanup := writeFeed(t, lintFeed)
+	defer cleanup()
+
+	// act
+	none := run([]string{"lint", "-fail", "none", path}, ioutil.Discard, ioutil.Discard)
+	rss := run([]string{"lint", "-profile", "", path}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 0, none)
+	assert.Equal(t, 4, rss)
+}
+
+func TestLintWarning(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	feed := bytes.Replace([]byte(lintFeed), []byte(`<guid isPermaLink="false">2</guid>`), []byte("<link>http://example.com/2</link>"), 1)
+	path, cleanup := writeFeed(t, string(feed))
+	defer cleanup()
+	var stdout bytes.Buffer
+
+	// act
+	warning := run([]string{"lint", "-profile", "rss", path}, &stdout, ioutil.Discard)
+	ignored := run([]string{"lint", "-profile", "rss", "-fail", "error", path}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 3, warning)
+	assert.Contains(t, stdout.String(), ":14: warning Items[1].GUID:")
+	assert.Equal(t, 0, ignored)
+}
+
+func TestLintJSON(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path, cleanup := writeFeed(t, lintFeed)
+	defer cleanup()
+	var stdout bytes.Buffer
+
+	// act
+	code := run([]string{"lint", "-profile", "rss", "-format", "json", path}, &stdout, ioutil.Discard)
+	var out jsonLint
+	err := json.Unmarshal(stdout.Bytes(), &out)
+
+	// assert
+	assert.Equal(t, 4, code)
+	assert.NoError(t, err)
+	assert.Equal(t, path, out.File)
+	assert.EqualValues(t, []string{"rss"}, out.Profiles)
+	assert.EqualValues(t, []*jsonFinding{{
+		Severity: "error",
+		Path:     "Items[1].Link",
+		Rule:     "rss-item-link",
+		Message:  "t: Link is required when not using Enclosure",
+		Line:     14,
+	}}, out.Findings)
+}
+
+func TestLintSARIF(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(lintFeed))
+	}))
+	defer s.Close()
+	var stdout bytes.Buffer
+
+	// act
+	code := run([]string{"lint", "-format", "sarif", s.URL + "/feed.xml"}, &stdout, ioutil.Discard)
+	var out sarifLog
+	err := json.Unmarshal(stdout.Bytes(), &out)
+
+	// assert
+	assert.Equal(t, 4, code)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.1.0", out.Version)
+	if assert.Len(t, out.Runs, 1) {
+		run := out.Runs[0]
+		assert.Equal(t, "rss-item-link", run.Tool.Driver.Rules[0].ID)
+		r := run.Results[0]
+		assert.Equal(t, "rss-item-link", r.RuleID)
+		assert.Equal(t, "error", r.Level)
+		assert.Equal(t, s.URL+"/feed.xml", r.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 14, r.Locations[0].PhysicalLocation.Region.StartLine)
+		assert.Equal(t, "Items[1].Link", r.Locations[0].LogicalLocations[0].FullyQualifiedName)
+		for _, r := range run.Results {
+			if r.RuleID == "apple-channel-image" {
+				assert.Equal(t, 3, r.Locations[0].PhysicalLocation.Region.StartLine)
+				assert.Equal(t, "IImage", r.Locations[0].LogicalLocations[0].FullyQualifiedName)
+			}
+		}
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	path, cleanup := writeFeed(t, "<rss></rss>")
+	defer cleanup()
+	var stderr bytes.Buffer
+
+	// act
+	invalid := run([]string{"lint", path}, ioutil.Discard, &stderr)
+	missing := run([]string{"lint", path + ".missing"}, ioutil.Discard, ioutil.Discard)
+	profile := run([]string{"lint", "-profile", "google", path}, ioutil.Discard, ioutil.Discard)
+	format := run([]string{"lint", "-format", "xml", path}, ioutil.Discard, ioutil.Discard)
+	severity := run([]string{"lint", "-fail", "fatal", path}, ioutil.Discard, ioutil.Discard)
+	info := run([]string{"lint", "-fail", "info", path}, ioutil.Discard, ioutil.Discard)
+
+	// assert
+	assert.Equal(t, 1, invalid)
+	assert.Equal(t, "podcast lint: podcast.Decode: channel is required\n", stderr.String())
+	assert.Equal(t, 1, missing)
+	assert.Equal(t, 1, profile)
+	assert.Equal(t, 1, format)
+	assert.Equal(t, 1, severity)
+	assert.Equal(t, 1, info)
+}
@@ -0,0 +1,103 @@
+// Command podcast builds podcast feeds from directories of media files,
+// checks them and compares them, with the github.com/eduncan911/podcast
+// package.
+//
+// Usage:
+//
+//   podcast build [-config file] [-o file] dir
+//   podcast lint [-profile list] [-format text|json|sarif] [-fail severity] file-or-url
+//   podcast diff [-format text|json] old new
+//
+// The build command creates one episode per audio or video file in dir,
+// using the show config, podcast.yaml by default, for the channel and
+// writing the feed to feed.xml in dir.
+//
+// The lint command checks a feed with Podcast.Validate, printing the
+// findings as text, JSON or SARIF for code scanning, and exits with a
+// non-zero status by the worst severity found.
+//
+// The diff command prints the changes between two versions of a feed
+// with podcast.Diff, exiting with a non-zero status when they differ and
+// especially when an episode's GUID changed.
+//
+// See the usage of each command for the details.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// command is a subcommand of the podcast command.
+type command struct {
+	run   func(args []string, stdout, stderr io.Writer) error
+	usage string
+}
+
+var commands = map[string]*command{
+	"build": {runBuild, "build a feed from a directory of media files"},
+	"diff":  {runDiff, "print the changes between two versions of a feed"},
+	"lint":  {runLint, "check a feed against the Apple, Spotify and Podcasting 2.0 rules"},
+}
+
+// exitStatus is returned by commands exiting with a status other than 1
+// after their output.
+type exitStatus int
+
+func (s exitStatus) Error() string {
+	return "exit status " + strconv.Itoa(int(s))
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the subcommand in args, returning the exit status: 0 on
+// success, 1 when the command fails and 2 on usage errors.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || commands[args[0]] == nil {
+		usage(stderr)
+		return 2
+	}
+	if err := commands[args[0]].run(args[1:], stdout, stderr); err != nil {
+		if s, ok := err.(exitStatus); ok {
+			return int(s)
+		}
+		if err == flag.ErrHelp {
+			return 2
+		}
+		fmt.Fprintf(stderr, "podcast %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: podcast <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "The commands are:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].usage)
+	}
+}
+
+// newFlagSet returns the FlagSet of the command, writing its usage line
+// and flags to stderr.
+func newFlagSet(name, args string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: podcast %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
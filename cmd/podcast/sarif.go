@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/eduncan911/podcast"
+)
+
+// Specifications: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+//
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInfoURI = "https://github.com/eduncan911/podcast"
+)
+
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   *sarifMessage    `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+	LogicalLocations []*sarifLogical        `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion   `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogical struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevels are the SARIF levels of the Severities.
+var sarifLevels = map[podcast.Severity]string{
+	podcast.SeverityInfo:    "note",
+	podcast.SeverityWarning: "warning",
+	podcast.SeverityError:   "error",
+}
+
+// writeSARIF writes the Findings as a SARIF log for code scanning, with
+// the rules in the order first found.
+func writeSARIF(w io.Writer, l *lint) error {
+	driver := &sarifDriver{
+		Name:           "podcast lint",
+		InformationURI: sarifInfoURI,
+		Rules:          []*sarifRule{},
+	}
+	run := &sarifRun{
+		Tool:    &sarifTool{Driver: driver},
+		Results: []*sarifResult{},
+	}
+	uri := strings.TrimPrefix(l.name, "./")
+	rules := map[string]bool{}
+	for _, f := range l.report.Findings {
+		if !rules[f.Rule] {
+			rules[f.Rule] = true
+			driver.Rules = append(driver.Rules, &sarifRule{ID: f.Rule})
+		}
+		loc := &sarifLocation{
+			PhysicalLocation: &sarifPhysicalLocation{
+				ArtifactLocation: &sarifArtifact{URI: uri},
+			},
+		}
+		if line := l.lines.line(f); line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+		}
+		if len(f.Path) > 0 {
+			loc.LogicalLocations = []*sarifLogical{{FullyQualifiedName: f.Path}}
+		}
+		run.Results = append(run.Results, &sarifResult{
+			RuleID:    f.Rule,
+			Level:     sarifLevels[f.Severity],
+			Message:   &sarifMessage{Text: f.Message},
+			Locations: []*sarifLocation{loc},
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(&sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []*sarifRun{run},
+	})
+}
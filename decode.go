@@ -0,0 +1,294 @@
+package podcast
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// decodePrefixes maps the namespaces used by Encode back to the prefixes
+// found in the struct tags.  Undeclared prefixes map to themselves so that
+// sloppy feeds still decode.
+var decodePrefixes = map[string]string{
+	itunesNS:  "itunes",
+	atomNS:    "atom",
+	podNS:     "podcast",
+	fhNS:      "fh",
+	"itunes":  "itunes",
+	"atom":    "atom",
+	"podcast": "podcast",
+	"fh":      "fh",
+}
+
+// decodeNumbers are the numeric elements, and attributes as
+// "element@attr", which are dropped when they cannot be parsed so that
+// they are left at their zero values.
+var decodeNumbers = map[string]func(string) error{
+	"ttl":            parseInt,
+	"width":          parseInt,
+	"height":         parseInt,
+	"itunes:season":  parseInt,
+	"itunes:episode": parseInt,
+	"hour":           parseInt,
+	"cloud@port":     parseInt,
+
+	"podcast:valueRecipient@split": parseInt,
+	"podcast:valueRecipient@fee":   parseBool,
+}
+
+// dateLayouts are the RSS date formats accepted when decoding, most
+// common first.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339,
+}
+
+// Decode reads an RSS 2.0 and iTunes podcast feed from the io.Reader and
+// returns the Podcast it represents.  It is the reverse of Podcast.Encode.
+//
+// Besides the fields marshalled as-is, the following fields are
+// repopulated from their formatted counterparts:
+//
+//   * Item.GUID from Item.GUIDFormatted
+//   * Item.PubDate from Item.PubDateFormatted
+//   * Item.Author from Item.AuthorFormatted
+//   * Enclosure.Length from Enclosure.LengthFormatted
+//   * Enclosure.Type from Enclosure.TypeFormatted
+//   * AtomLink from the AtomLinks with rel="self"
+//
+// Dates, lengths and numbers that cannot be parsed, such as a TTL of
+// "60 min", are left at their zero values instead of failing the whole
+// feed.
+//
+// Feeds are read as UTF-8, or as Windows-1252 when declared as such or
+// as ISO-8859-1 or US-ASCII.  Other encodings return an error.
+func Decode(r io.Reader) (*Podcast, error) {
+	var wrapped podcastWrapper
+	x := xml.NewDecoder(r)
+	x.CharsetReader = charsetReader
+	d := xml.NewTokenDecoder(&prefixReader{d: x})
+	if err := d.Decode(&wrapped); err != nil {
+		return nil, errors.Wrap(err, "podcast.Decode: d.Decode returned error")
+	}
+	if wrapped.Channel == nil {
+		return nil, errors.New("podcast.Decode: channel is required")
+	}
+
+	p := wrapped.Channel
+	p.encode = encoder
+	for n, l := range p.AtomLinks {
+		if l.Rel == "self" {
+			p.AtomLink = l
+			p.AtomLinks = append(p.AtomLinks[:n:n], p.AtomLinks[n+1:]...)
+			break
+		}
+	}
+	if len(p.AtomLinks) == 0 {
+		p.AtomLinks = nil
+	}
+	for _, i := range p.Items {
+		decodeItem(i)
+	}
+	return p, nil
+}
+
+func decodeItem(i *Item) {
+	if i.GUIDFormatted != nil {
+		i.GUID = i.GUIDFormatted.Value
+	}
+	i.PubDate = parseDate(i.PubDateFormatted)
+	if len(i.AuthorFormatted) > 0 {
+		i.Author = parseAuthorFormatted(i.AuthorFormatted)
+	}
+	if i.Enclosure != nil {
+		i.Enclosure.Length, _ = strconv.ParseInt(i.Enclosure.LengthFormatted, 10, 64)
+		i.Enclosure.Type = parseEnclosureType(i.Enclosure.TypeFormatted)
+	}
+}
+
+// prefixReader is a xml.TokenReader that rewrites namespaced names into
+// the "prefix:local" form used by the struct tags of this package, and
+// drops the invalid decodeNumbers.
+type prefixReader struct {
+	d       *xml.Decoder
+	pending []xml.Token
+}
+
+func (r *prefixReader) Token() (xml.Token, error) {
+	if len(r.pending) > 0 {
+		t := r.pending[0]
+		r.pending = r.pending[1:]
+		return t, nil
+	}
+	t, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if e, ok := t.(xml.StartElement); ok {
+		if _, ok := decodeNumbers[e.Name.Local]; ok {
+			return r.number(e)
+		}
+	}
+	return t, nil
+}
+
+// number reads ahead to the end of the element e of the decodeNumbers,
+// which is dropped when its text cannot be parsed, so that no zero is
+// appended to a slice such as SkipHours.Hours.
+func (r *prefixReader) number(e xml.StartElement) (xml.Token, error) {
+	var tokens []xml.Token
+	var text []byte
+	for depth := 1; depth > 0; {
+		t, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		switch v := t.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			text = append(text, v...)
+		}
+		tokens = append(tokens, xml.CopyToken(t))
+	}
+	if !validNumber(e.Name.Local, string(text)) {
+		return r.Token()
+	}
+	r.pending = tokens
+	return e, nil
+}
+
+// next returns the next token of the xml.Decoder with the names rewritten.
+func (r *prefixReader) next() (xml.Token, error) {
+	t, err := r.d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch e := t.(type) {
+	case xml.StartElement:
+		e.Name = prefixName(e.Name)
+		attrs := make([]xml.Attr, 0, len(e.Attr))
+		for _, a := range e.Attr {
+			if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+				continue
+			}
+			a.Name = prefixName(a.Name)
+			if !validNumber(e.Name.Local+"@"+a.Name.Local, a.Value) {
+				a.Value = ""
+			}
+			attrs = append(attrs, a)
+		}
+		e.Attr = attrs
+		return e, nil
+	case xml.EndElement:
+		e.Name = prefixName(e.Name)
+		return e, nil
+	}
+	return t, nil
+}
+
+// validNumber reports whether s is empty or parses as the decodeNumbers
+// of name, which any other name accepts.
+func validNumber(name, s string) bool {
+	parse, ok := decodeNumbers[name]
+	s = strings.TrimSpace(s)
+	return !ok || len(s) == 0 || parse(s) == nil
+}
+
+func parseInt(s string) error {
+	_, err := strconv.Atoi(s)
+	return err
+}
+
+func parseBool(s string) error {
+	_, err := strconv.ParseBool(s)
+	return err
+}
+
+func prefixName(n xml.Name) xml.Name {
+	if prefix, ok := decodePrefixes[n.Space]; ok {
+		return xml.Name{Local: prefix + ":" + n.Local}
+	}
+	return n
+}
+
+var parseDate = func(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// parseAuthorFormatted reverses parseAuthorNameEmail.
+var parseAuthorFormatted = func(s string) *Author {
+	s = strings.TrimSpace(s)
+	a := &Author{Email: s}
+	if n := strings.Index(s, " ("); n > 0 && strings.HasSuffix(s, ")") {
+		a.Email = s[:n]
+		a.Name = s[n+2 : len(s)-1]
+	}
+	return a
+}
+
+// charsetReader is the xml.Decoder CharsetReader, reading ISO-8859-1 and
+// US-ASCII as their Windows-1252 superset, as web browsers do.
+func charsetReader(label string, r io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "windows-1252", "cp1252", "iso-8859-1", "iso8859-1", "latin1", "l1", "us-ascii", "ascii":
+		return &windows1252Reader{r: bufio.NewReader(r)}, nil
+	}
+	return nil, errors.New("podcast.Decode: unsupported encoding " + label)
+}
+
+// windows1252 are the characters of the bytes 0x80 to 0x9F, the others
+// being the same as in Unicode.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// windows1252Reader converts Windows-1252 to UTF-8.
+type windows1252Reader struct {
+	r       io.ByteReader
+	pending []byte
+}
+
+func (r *windows1252Reader) Read(p []byte) (int, error) {
+	var buf [utf8.UTFMax]byte
+	for len(r.pending) < len(p) {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if len(r.pending) > 0 {
+				break
+			}
+			return 0, err
+		}
+		c := rune(b)
+		if b >= 0x80 && b < 0xA0 {
+			c = windows1252[b-0x80]
+		}
+		n := utf8.EncodeRune(buf[:], c)
+		r.pending = append(r.pending, buf[:n]...)
+	}
+	n := copy(p, r.pending)
+	r.pending = append(r.pending[:0], r.pending[n:]...)
+	return n, nil
+}
@@ -0,0 +1,195 @@
+package podcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInvalidXML(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader("<rss><channel><title>oops</channel></rss>")
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.Nil(t, p)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "d.Decode returned error")
+}
+
+func TestDecodeMissingChannel(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss version="2.0"></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.Nil(t, p)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "channel is required")
+}
+
+func TestDecodeUnknownEnclosureTypeAndDate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss><channel><item>
+		<title>t</title>
+		<pubDate>yesterday</pubDate>
+		<author>me@janedoe.com</author>
+		<enclosure url="http://e.com/1.xyz" length="abc" type="x/unknown"></enclosure>
+	</item></channel></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, p.Items, 1)
+	assert.Nil(t, p.Items[0].PubDate)
+	assert.EqualValues(t, "me@janedoe.com", p.Items[0].Author.Email)
+	assert.EqualValues(t, "", p.Items[0].Author.Name)
+	assert.EqualValues(t, 0, p.Items[0].Enclosure.Length)
+	assert.EqualValues(t, "application/octet-stream", p.Items[0].Enclosure.Type.String())
+}
+
+func TestDecodeInvalidNumbers(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss><channel>
+		<title>t</title>
+		<ttl>60 min</ttl>
+		<image><url>http://e.com/i.jpg</url><width>wide</width><height> 80 </height></image>
+	</channel></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "t", p.Title)
+	assert.EqualValues(t, 0, p.TTL)
+	assert.EqualValues(t, 0, p.Image.Width)
+	assert.EqualValues(t, 80, p.Image.Height)
+}
+
+func TestDecodeInvalidSeasonEpisode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><channel>
+		<item><title>bonus</title><itunes:season>2</itunes:season><itunes:episode>Bonus</itunes:episode></item>
+		<item><title>trailer</title><itunes:season>S1</itunes:season><itunes:episode>3</itunes:episode></item>
+	</channel></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, p.Items, 2)
+	assert.EqualValues(t, 2, p.Items[0].ISeason)
+	assert.EqualValues(t, 0, p.Items[0].IEpisode)
+	assert.EqualValues(t, 0, p.Items[1].ISeason)
+	assert.EqualValues(t, 3, p.Items[1].IEpisode)
+}
+
+func TestDecodeInvalidSkipHoursAndCloud(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss><channel>
+		<cloud domain="rpc.example.com" port="eighty" path="/RPC2" registerProcedure="p" protocol="xml-rpc"/>
+		<skipHours><hour>x</hour><hour>5</hour></skipHours>
+	</channel></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, []int{5}, p.SkipHoursFormatted.Hours)
+	assert.EqualValues(t, "rpc.example.com", p.CloudFormatted.Domain)
+	assert.EqualValues(t, 0, p.CloudFormatted.Port)
+}
+
+func TestDecodeInvalidValueRecipient(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := strings.NewReader(`<rss xmlns:podcast="https://podcastindex.org/namespace/1.0"><channel>
+		<podcast:value type="lightning" method="keysend">
+			<podcast:valueRecipient name="host" type="node" address="abc" split="ninety" fee="yes"/>
+		</podcast:value>
+	</channel></rss>`)
+
+	// act
+	p, err := podcast.Decode(r)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, p.PValue.Recipients, 1)
+	assert.EqualValues(t, "host", p.PValue.Recipients[0].Name)
+	assert.EqualValues(t, 0, p.PValue.Recipients[0].Split)
+	assert.False(t, p.PValue.Recipients[0].Fee)
+}
+
+func TestDecodeCharset(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	latin1 := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>" +
+		"<rss><channel><title>Caf\xe9 \x93live\x94</title></channel></rss>"
+	other := `<?xml version="1.0" encoding="EBCDIC"?><rss><channel></channel></rss>`
+
+	// act
+	p, err := podcast.Decode(strings.NewReader(latin1))
+	_, errOther := podcast.Decode(strings.NewReader(other))
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Café “live”", p.Title)
+	assert.Error(t, errOther)
+	assert.Contains(t, errOther.Error(), "unsupported encoding EBCDIC")
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "desc", &createdDate, &updatedDate)
+	p.AddAuthor("Jane Doe", "me@janedoe.com")
+	p.AddAtomLink("http://example.com/feed.rss")
+	p.AddCategory("Technology", []string{"Podcasting"})
+	p.AddImage("http://example.com/i.jpg")
+	p.AddSummary(`a <a href="http://example.com">link</a>`)
+	p.IOwner = &podcast.Author{Name: "Jane Doe", Email: "me@janedoe.com"}
+	i := podcast.Item{Title: "ep 1", Description: "desc 1", PubDate: &pubDate}
+	i.AddEnclosure("http://example.com/1.m4a", podcast.M4A, 1234)
+	i.AddDuration(99)
+	if _, err := p.AddItem(i); err != nil {
+		t.Fatal(err)
+	}
+	want := p.String()
+
+	// act
+	d, err := podcast.Decode(bytes.NewBufferString(want))
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, want, d.String())
+	assert.EqualValues(t, podcast.M4A, d.Items[0].Enclosure.Type)
+	assert.EqualValues(t, 1234, d.Items[0].Enclosure.Length)
+	assert.True(t, pubDate.Equal(*d.Items[0].PubDate))
+}
@@ -0,0 +1,305 @@
+package podcast
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ChangeType specifies the kind of a Change between two Podcasts.
+const (
+	ChangeAdded ChangeType = iota
+	ChangeRemoved
+	ChangeModified
+	ChangeGUID
+)
+
+// ChangeType specifies the kind of a Change between two Podcasts.
+type ChangeType int
+
+// String returns the lowercase name of the ChangeType.
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	case ChangeGUID:
+		return "guid"
+	}
+	return "unknown"
+}
+
+// Change is a difference between two versions of a Podcast found by Diff.
+type Change struct {
+	// Type is the kind of change:
+	//   * ChangeAdded and ChangeRemoved for episodes, with the Title.
+	//   * ChangeModified for a Field of the channel, when GUID is empty,
+	//     or of the episode, from Old to New.
+	//   * ChangeGUID for an episode whose GUID changed from Old to New,
+	//     which makes apps download it again.
+	Type ChangeType
+	// GUID is the GUID of the episode, or empty for the channel.
+	GUID string
+	// Title is the Title of the episode.
+	Title string
+	// Field is the modified field, such as "Enclosure.URL".
+	Field string
+	// Old and New are the values of the modified Field.
+	Old string
+	New string
+}
+
+// String formats the Change as a single line.
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded, ChangeRemoved:
+		return fmt.Sprintf("%s item %q: %q", c.Type, c.GUID, c.Title)
+	case ChangeGUID:
+		return fmt.Sprintf("guid of item %q changed: %q => %q", c.Title, c.Old, c.New)
+	}
+	if len(c.GUID) == 0 {
+		return fmt.Sprintf("modified %s: %q => %q", c.Field, c.Old, c.New)
+	}
+	return fmt.Sprintf("modified item %q %s: %q => %q", c.GUID, c.Field, c.Old, c.New)
+}
+
+// Diff returns the Changes from a to b, such as yesterday's and today's
+// versions of a feed:
+//   * The channel fields that were modified, except for LastBuildDate
+//     and Generator which change with every build.
+//   * The episodes that were added, removed or modified, matched by their
+//     GUID, or else by the Enclosure URL or Link.
+//   * The episodes whose GUID changed, found as a removed and an added
+//     episode with the same Enclosure URL or Link, or else the same Title
+//     and PubDate, or ISeason and IEpisode, such as when the GUID is the
+//     Enclosure URL and the media moved to a new CDN.
+//
+// The Items are compared as encoded, so they are expected to be added with
+// AddItem or read with Decode.  The Changes are in the order of the
+// channel fields, the Items of b, then the removed Items of a.
+func Diff(a, b *Podcast) []Change {
+	var changes []Change
+	for _, f := range channelFields {
+		if from, to := f.value(a), f.value(b); from != to {
+			changes = append(changes, Change{
+				Type:  ChangeModified,
+				Field: f.name,
+				Old:   from,
+				New:   to,
+			})
+		}
+	}
+
+	// the Items of a with the same key are matched in order
+	olds := make(map[string][]*Item, len(a.Items))
+	for _, i := range a.Items {
+		key := itemKey(i)
+		olds[key] = append(olds[key], i)
+	}
+	matched := make(map[*Item]bool, len(a.Items))
+	var added []*Item
+	for _, i := range b.Items {
+		key := itemKey(i)
+		if len(olds[key]) == 0 {
+			added = append(added, i)
+			continue
+		}
+		old := olds[key][0]
+		olds[key] = olds[key][1:]
+		matched[old] = true
+		changes = append(changes, diffItem(old, i)...)
+	}
+
+	// an added and a removed Item of the same media, or else of the same
+	// episode, is a changed GUID
+	for _, i := range added {
+		old := unmatchedItem(a.Items, matched, i, sameMedia)
+		if old == nil {
+			old = unmatchedItem(a.Items, matched, i, sameEpisode)
+		}
+		if old == nil {
+			changes = append(changes, Change{Type: ChangeAdded, GUID: i.GUID, Title: i.Title})
+			continue
+		}
+		matched[old] = true
+		changes = append(changes, Change{
+			Type:  ChangeGUID,
+			GUID:  i.GUID,
+			Title: i.Title,
+			Field: "GUID",
+			Old:   old.GUID,
+			New:   i.GUID,
+		})
+		changes = append(changes, diffItem(old, i)...)
+	}
+	for _, o := range a.Items {
+		if !matched[o] {
+			changes = append(changes, Change{Type: ChangeRemoved, GUID: o.GUID, Title: o.Title})
+		}
+	}
+	return changes
+}
+
+func diffItem(a, b *Item) []Change {
+	var changes []Change
+	for _, f := range itemFields {
+		if from, to := f.value(a), f.value(b); from != to {
+			changes = append(changes, Change{
+				Type:  ChangeModified,
+				GUID:  b.GUID,
+				Title: b.Title,
+				Field: f.name,
+				Old:   from,
+				New:   to,
+			})
+		}
+	}
+	return changes
+}
+
+// itemKey returns the GUID of the Item, or else its Enclosure URL, Link
+// or Title.
+func itemKey(i *Item) string {
+	switch {
+	case len(i.GUID) > 0:
+		return "guid " + i.GUID
+	case i.Enclosure != nil && len(i.Enclosure.URL) > 0:
+		return "url " + i.Enclosure.URL
+	case len(i.Link) > 0:
+		return "link " + i.Link
+	}
+	return "title " + i.Title
+}
+
+// unmatchedItem returns the first Item of items that is not matched and is
+// the same as i, or nil when there is none.
+func unmatchedItem(items []*Item, matched map[*Item]bool, i *Item, same func(a, b *Item) bool) *Item {
+	for _, o := range items {
+		if !matched[o] && same(o, i) {
+			return o
+		}
+	}
+	return nil
+}
+
+// sameMedia reports whether the Items have the same Enclosure URL, or
+// else the same Link.
+func sameMedia(a, b *Item) bool {
+	if a.Enclosure != nil && b.Enclosure != nil && len(a.Enclosure.URL) > 0 {
+		return a.Enclosure.URL == b.Enclosure.URL
+	}
+	return len(a.Link) > 0 && a.Link == b.Link
+}
+
+// sameEpisode reports whether the Items have the same Title and PubDate,
+// or else the same ISeason and IEpisode.
+func sameEpisode(a, b *Item) bool {
+	if len(a.Title) > 0 && len(a.PubDateFormatted) > 0 &&
+		a.Title == b.Title && a.PubDateFormatted == b.PubDateFormatted {
+		return true
+	}
+	return a.IEpisode > 0 && a.ISeason == b.ISeason && a.IEpisode == b.IEpisode
+}
+
+var channelFields = []struct {
+	name  string
+	value func(p *Podcast) string
+}{
+	{"Title", func(p *Podcast) string { return p.Title }},
+	{"Link", func(p *Podcast) string { return p.Link }},
+	{"Description", func(p *Podcast) string { return p.Description }},
+	{"Category", func(p *Podcast) string { return p.Category }},
+	{"Copyright", func(p *Podcast) string { return p.Copyright }},
+	{"Language", func(p *Podcast) string { return p.Language }},
+	{"ManagingEditor", func(p *Podcast) string { return p.ManagingEditor }},
+	{"PubDate", func(p *Podcast) string { return p.PubDate }},
+	{"TTL", func(p *Podcast) string { return strconv.Itoa(p.TTL) }},
+	{"Image.URL", func(p *Podcast) string {
+		if p.Image == nil {
+			return ""
+		}
+		return p.Image.URL
+	}},
+	{"AtomLink.HREF", func(p *Podcast) string {
+		if p.AtomLink == nil {
+			return ""
+		}
+		return p.AtomLink.HREF
+	}},
+	{"IAuthor", func(p *Podcast) string { return p.IAuthor }},
+	{"ISubtitle", func(p *Podcast) string { return p.ISubtitle }},
+	{"ISummary", func(p *Podcast) string {
+		if p.ISummary == nil {
+			return ""
+		}
+		return p.ISummary.Text
+	}},
+	{"IBlock", func(p *Podcast) string { return p.IBlock }},
+	{"IImage", func(p *Podcast) string {
+		if p.IImage == nil {
+			return ""
+		}
+		return p.IImage.HREF
+	}},
+	{"IExplicit", func(p *Podcast) string { return p.IExplicit }},
+	{"IComplete", func(p *Podcast) string { return p.IComplete }},
+	{"INewFeedURL", func(p *Podcast) string { return p.INewFeedURL }},
+	{"IType", func(p *Podcast) string { return p.IType }},
+	{"IOwner", func(p *Podcast) string {
+		if p.IOwner == nil {
+			return ""
+		}
+		return parseAuthorNameEmail(p.IOwner)
+	}},
+}
+
+var itemFields = []struct {
+	name  string
+	value func(i *Item) string
+}{
+	{"Title", func(i *Item) string { return i.Title }},
+	{"Link", func(i *Item) string { return i.Link }},
+	{"Description", func(i *Item) string { return i.Description }},
+	{"Author", func(i *Item) string { return i.AuthorFormatted }},
+	{"Category", func(i *Item) string { return i.Category }},
+	{"PubDate", func(i *Item) string { return i.PubDateFormatted }},
+	{"Enclosure.URL", func(i *Item) string {
+		if i.Enclosure == nil {
+			return ""
+		}
+		return i.Enclosure.URL
+	}},
+	{"Enclosure.Length", func(i *Item) string {
+		if i.Enclosure == nil {
+			return ""
+		}
+		return i.Enclosure.LengthFormatted
+	}},
+	{"Enclosure.Type", func(i *Item) string {
+		if i.Enclosure == nil {
+			return ""
+		}
+		return i.Enclosure.TypeFormatted
+	}},
+	{"ISubtitle", func(i *Item) string { return i.ISubtitle }},
+	{"ISummary", func(i *Item) string {
+		if i.ISummary == nil {
+			return ""
+		}
+		return i.ISummary.Text
+	}},
+	{"IImage", func(i *Item) string {
+		if i.IImage == nil {
+			return ""
+		}
+		return i.IImage.HREF
+	}},
+	{"IDuration", func(i *Item) string { return i.IDuration }},
+	{"IExplicit", func(i *Item) string { return i.IExplicit }},
+	{"ITitle", func(i *Item) string { return i.ITitle }},
+	{"IEpisodeType", func(i *Item) string { return i.IEpisodeType }},
+	{"ISeason", func(i *Item) string { return strconv.Itoa(i.ISeason) }},
+	{"IEpisode", func(i *Item) string { return strconv.Itoa(i.IEpisode) }},
+}
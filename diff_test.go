@@ -0,0 +1,171 @@
+package podcast_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func diffFeed(t *testing.T, guids ...string) *podcast.Podcast {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	for n, guid := range guids {
+		i := podcast.Item{GUID: guid, Title: fmt.Sprint("Episode ", n+1), Description: "desc", PubDate: &pubDate}
+		i.AddEnclosure(fmt.Sprint("http://example.com/", n+1, ".mp3"), podcast.MP3, 183)
+		_, err := p.AddItem(i)
+		assert.NoError(t, err)
+	}
+	return &p
+}
+
+func TestDiffSame(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "1", "2"), diffFeed(t, "1", "2")
+	b.AddLastBuildDate(&createdDate)
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.Len(t, changes, 0)
+}
+
+func TestDiffItems(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "1", "2", "3"), diffFeed(t, "1", "2", "3")
+	b.Title = "new title"
+	b.Items = append(b.Items[1:2], &podcast.Item{GUID: "4", Title: "Episode 4"})
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeModified, Field: "Title", Old: "title", New: "new title"},
+		{Type: podcast.ChangeAdded, GUID: "4", Title: "Episode 4"},
+		{Type: podcast.ChangeRemoved, GUID: "1", Title: "Episode 1"},
+		{Type: podcast.ChangeRemoved, GUID: "3", Title: "Episode 3"},
+	}, changes)
+}
+
+func TestDiffModifiedItem(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "1"), diffFeed(t, "1")
+	b.Items[0].Enclosure.URL = "http://cdn.example.com/1.mp3"
+	b.Items[0].Enclosure.LengthFormatted = "184"
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeModified, GUID: "1", Title: "Episode 1", Field: "Enclosure.URL",
+			Old: "http://example.com/1.mp3", New: "http://cdn.example.com/1.mp3"},
+		{Type: podcast.ChangeModified, GUID: "1", Title: "Episode 1", Field: "Enclosure.Length",
+			Old: "183", New: "184"},
+	}, changes)
+	assert.Equal(t, `modified item "1" Enclosure.Length: "183" => "184"`, changes[1].String())
+}
+
+func TestDiffGUIDChanged(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "1", "2"), diffFeed(t, "1", "http://example.com/2.mp3")
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeGUID, GUID: "http://example.com/2.mp3", Title: "Episode 2", Field: "GUID",
+			Old: "2", New: "http://example.com/2.mp3"},
+	}, changes)
+	assert.Equal(t, `guid of item "Episode 2" changed: "2" => "http://example.com/2.mp3"`,
+		changes[0].String())
+}
+
+func TestDiffGUIDChangedWithCDN(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "https://old-cdn.com/ep1.mp3"), diffFeed(t, "https://new-cdn.com/ep1.mp3")
+	a.Items[0].Enclosure.URL = "https://old-cdn.com/ep1.mp3"
+	b.Items[0].Enclosure.URL = "https://new-cdn.com/ep1.mp3"
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeGUID, GUID: "https://new-cdn.com/ep1.mp3", Title: "Episode 1", Field: "GUID",
+			Old: "https://old-cdn.com/ep1.mp3", New: "https://new-cdn.com/ep1.mp3"},
+		{Type: podcast.ChangeModified, GUID: "https://new-cdn.com/ep1.mp3", Title: "Episode 1",
+			Field: "Enclosure.URL", Old: "https://old-cdn.com/ep1.mp3", New: "https://new-cdn.com/ep1.mp3"},
+	}, changes)
+}
+
+func TestDiffDuplicateGUIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a, b := diffFeed(t, "1", "1"), diffFeed(t, "1", "1")
+	b.Items[1].Title = "Episode 3"
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeModified, GUID: "1", Title: "Episode 3", Field: "Title",
+			Old: "Episode 2", New: "Episode 3"},
+	}, changes)
+}
+
+func TestDiffWithoutGUIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	a := &podcast.Podcast{Items: []*podcast.Item{{Title: "a", Link: "http://example.com/a"}}}
+	b := &podcast.Podcast{Items: []*podcast.Item{{Title: "b", Link: "http://example.com/a"}}}
+
+	// act
+	changes := podcast.Diff(a, b)
+
+	// assert
+	assert.EqualValues(t, []podcast.Change{
+		{Type: podcast.ChangeModified, Title: "b", Field: "Title", Old: "a", New: "b"},
+	}, changes)
+}
+
+func TestChangeString(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	changes := []podcast.Change{
+		{Type: podcast.ChangeAdded, GUID: "4", Title: "Episode 4"},
+		{Type: podcast.ChangeRemoved, GUID: "1", Title: "Episode 1"},
+		{Type: podcast.ChangeModified, Field: "Title", Old: "a", New: "b"},
+	}
+
+	// act
+	var out []string
+	for _, c := range changes {
+		out = append(out, c.String())
+	}
+
+	// assert
+	assert.EqualValues(t, []string{
+		`added item "4": "Episode 4"`,
+		`removed item "1": "Episode 1"`,
+		`modified Title: "a" => "b"`,
+	}, out)
+	assert.Equal(t, "unknown", podcast.ChangeType(9).String())
+}
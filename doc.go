@@ -27,11 +27,11 @@
 //
 // Go Modules
 //
-// This library is supported on GoLang 1.7 and higher.
+// This library is supported on GoLang 1.10 and higher.
 //
 // We have implemented Go Modules support and the CI pipeline shows it working with
-// new installs, tested with Go 1.13.  To keep 1.7 compatibility, we use
-// `go mod vendor` to maintain the `vendor/` folder for older 1.7 and later runtimes.
+// new installs, tested with Go 1.13.  To keep 1.10 compatibility, we use
+// `go mod vendor` to maintain the `vendor/` folder for older 1.10 and later runtimes.
 //
 // If either runtime has an issue, please create an Issue and I will address.
 //
@@ -40,7 +40,8 @@
 // For version 1.x, you are not restricted in having full control over your feeds.
 // You may choose to skip the API methods and instead use the structs directly.  The
 // fields have been grouped by RSS 2.0 and iTunes fields with iTunes specific fields
-// all prefixed with the letter `I`.
+// all prefixed with the letter `I`.  Likewise, Podcasting 2.0 fields from the
+// Podcast Index `podcast:` namespace are all prefixed with the letter `P`.
 //
 // However, do note that the 2.x version currently in progress will break this
 // extensibility and enforce API methods going forward. This is to ensure that the feed
@@ -67,11 +68,12 @@
 //
 //   $ go-fuzz
 //   2020/02/13 07:27:32 -func flag not provided, but multiple fuzz functions available:
-//   FuzzItemAddDuration, FuzzItemAddEnclosure, FuzzItemAddImage, FuzzItemAddPubDate,
-//   FuzzItemAddSummary, FuzzPodcastAddAtomLink, FuzzPodcastAddAuthor, FuzzPodcastAddCategory,
-//   FuzzPodcastAddImage, FuzzPodcastAddItem, FuzzPodcastAddLastBuildDate, FuzzPodcastAddPubDate,
-//   FuzzPodcastAddSubTitle, FuzzPodcastAddSummary, FuzzPodcastBytes, FuzzPodcastEncode,
-//   FuzzPodcastNew
+//   FuzzDecode, FuzzDecodeTranscript, FuzzItemAddDuration, FuzzItemAddEnclosure,
+//   FuzzItemAddImage, FuzzItemAddPubDate, FuzzItemAddSummary, FuzzPodcastAddAtomLink,
+//   FuzzPodcastAddAuthor, FuzzPodcastAddCategory, FuzzPodcastAddImage, FuzzPodcastAddItem,
+//   FuzzPodcastAddLastBuildDate, FuzzPodcastAddPubDate, FuzzPodcastAddSubTitle,
+//   FuzzPodcastAddSummary, FuzzPodcastBytes, FuzzPodcastEncode, FuzzPodcastNew,
+//   FuzzParseChapters, FuzzProbeMP4
 //
 // If you do find an issue, please raise an issue immediately and I will quickly address.
 //
@@ -100,6 +102,38 @@
 //
 // Release Notes
 //
+// v1.5.0
+//   * Go 1.10 or later is now required
+//   * Add Decode to parse RSS 2.0 and iTunes feeds back into a Podcast
+//   * Add Podcasting 2.0 locked, funding, transcript and chapters tags
+//   * Add iTunes type, episodeType, season, episode and title tags
+//   * Add the 2019 Apple category taxonomy with AddAppleCategory validation
+//   * Add Podcast.Validate with RSS, Apple, Spotify and Podcasting 2.0 profiles
+//   * Add WithClock and WithStrictDates options for deterministic dates
+//   * Add Podcast.EncodeAtom for Atom 1.0 output
+//   * Add Podcast.EncodeJSONFeed for JSON Feed 1.1 output
+//   * Add Podcasting 2.0 value tag with lightning recipient splits
+//   * Add JSON Chapters encoding and ParseChapters for show notes
+//   * Add Transcript conversion between SRT, WebVTT, JSON and HTML
+//   * Add Item.AddEnclosureFromFile reading MP3 length and VBR duration
+//   * Add ProbeMP4 for MP4, M4A, M4V and MOV duration, chapters and cover art
+//   * Add Opus, Ogg, FLAC, AAC, WebM, WAV, HLS and M4B enclosure types
+//   * Add RegisterEnclosureType and inferring types by extension or content
+//   * EPUB is now application/epub+zip, use EPUBLegacy for document/x-epub
+//   * Add Handler serving feeds with ETag, Last-Modified, gzip and HEAD
+//   * Add Podcast.Pages and Podcast.Archives for RFC 5005 paged and archived feeds
+//   * Add AtomLinks for multiple atom:link tags with any rel
+//   * Add NewFeedWriter streaming Items for very large feeds
+//   * Add AddSkipHours, AddSkipDays and AddCloud for valid RSS elements
+//   * The Cloud, SkipHours and SkipDays strings are deprecated and converted when encoding
+//   * Add Podcast.AddHub and Publisher for WebSub notifications
+//   * Add the podping package batching Podping notifications of feed updates
+//   * Add the podcast command building feeds from directories of media files
+//   * Add the podcast lint command with text, JSON and SARIF output
+//   * Add Diff and the podcast diff command reporting changed GUIDs
+//   * Add GUID isPermaLink, the channel podcast:guid and WithStrictGUIDs
+//   * Add Podcast.MigrateTo and Redirect for moving feeds to new hosts
+//
 // v1.4.2
 //   * Slim down Go Modules for consumers (#32)
 //
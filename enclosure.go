@@ -1,6 +1,11 @@
 package podcast
 
-import "encoding/xml"
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"sync"
+)
 
 // EnclosureType specifies the type of the enclosure.
 const (
@@ -11,35 +16,178 @@ const (
 	MOV
 	PDF
 	EPUB
+	OPUS
+	OGG
+	FLAC
+	AAC
+	WEBM
+	WAV
+	HLS
+	M4B
+	EPUBLegacy
 )
 
 const (
 	enclosureDefault = "application/octet-stream"
+
+	// enclosureUnknown is returned for MIME types that do not map to any
+	// of the EnclosureType constants.
+	enclosureUnknown EnclosureType = -1
 )
 
 // EnclosureType specifies the type of the enclosure.
 type EnclosureType int
 
+// enclosureRegistration is the MIME type and file extensions of a
+// registered EnclosureType.
+type enclosureRegistration struct {
+	mime       string
+	extensions []string
+}
+
+var (
+	enclosureMu sync.RWMutex
+
+	// enclosureTypes holds the built-in types, in the order of the
+	// constants, followed by those added with RegisterEnclosureType.
+	//
+	// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+	enclosureTypes = []*enclosureRegistration{
+		M4A:        {"audio/x-m4a", []string{".m4a"}},
+		M4V:        {"video/x-m4v", []string{".m4v"}},
+		MP4:        {"video/mp4", []string{".mp4"}},
+		MP3:        {"audio/mpeg", []string{".mp3"}},
+		MOV:        {"video/quicktime", []string{".mov"}},
+		PDF:        {"application/pdf", []string{".pdf"}},
+		EPUB:       {"application/epub+zip", []string{".epub"}},
+		OPUS:       {"audio/opus", []string{".opus"}},
+		OGG:        {"audio/ogg", []string{".ogg", ".oga"}},
+		FLAC:       {"audio/flac", []string{".flac"}},
+		AAC:        {"audio/aac", []string{".aac"}},
+		WEBM:       {"video/webm", []string{".webm"}},
+		WAV:        {"audio/wav", []string{".wav"}},
+		HLS:        {"application/x-mpegURL", []string{".m3u8"}},
+		M4B:        {"audio/x-m4b", []string{".m4b"}},
+		EPUBLegacy: {"document/x-epub", nil},
+	}
+)
+
 // String returns the MIME type encoding of the specified EnclosureType.
 func (et EnclosureType) String() string {
-	// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
-	switch et {
-	case M4A:
-		return "audio/x-m4a"
-	case M4V:
-		return "video/x-m4v"
-	case MP4:
-		return "video/mp4"
-	case MP3:
-		return "audio/mpeg"
-	case MOV:
-		return "video/quicktime"
-	case PDF:
-		return "application/pdf"
-	case EPUB:
-		return "document/x-epub"
+	enclosureMu.RLock()
+	defer enclosureMu.RUnlock()
+	if et < 0 || int(et) >= len(enclosureTypes) {
+		return enclosureDefault
+	}
+	return enclosureTypes[et].mime
+}
+
+// isVideo reports whether the EnclosureType is a video format.
+func (et EnclosureType) isVideo() bool {
+	return strings.HasPrefix(et.String(), "video/")
+}
+
+// RegisterEnclosureType adds the MIME type as a new EnclosureType, which
+// is inferred from the file extensions such as ".mka" by
+// EnclosureTypeByExtension.
+//
+// Registering a MIME type that already exists, including the built-in
+// ones, returns the existing EnclosureType with the extensions added.
+func RegisterEnclosureType(mime string, extensions ...string) EnclosureType {
+	enclosureMu.Lock()
+	defer enclosureMu.Unlock()
+	var exts []string
+	for _, e := range extensions {
+		exts = append(exts, strings.ToLower(e))
+	}
+	for et, r := range enclosureTypes {
+		if strings.EqualFold(r.mime, mime) {
+			r.extensions = append(r.extensions, exts...)
+			return EnclosureType(et)
+		}
+	}
+	enclosureTypes = append(enclosureTypes, &enclosureRegistration{
+		mime:       mime,
+		extensions: exts,
+	})
+	return EnclosureType(len(enclosureTypes) - 1)
+}
+
+// EnclosureTypeByExtension returns the EnclosureType of the file
+// extension, such as ".mp3", reporting false when it is not registered.
+func EnclosureTypeByExtension(ext string) (EnclosureType, bool) {
+	enclosureMu.RLock()
+	defer enclosureMu.RUnlock()
+	ext = strings.ToLower(ext)
+	for et, r := range enclosureTypes {
+		for _, e := range r.extensions {
+			if e == ext {
+				return EnclosureType(et), true
+			}
+		}
+	}
+	return enclosureUnknown, false
+}
+
+// EnclosureTypeByContent returns the EnclosureType of the built-in
+// formats by sniffing the first bytes of the file, reporting false when
+// none match.  512 bytes are enough to detect every format.
+func EnclosureTypeByContent(b []byte) (EnclosureType, bool) {
+	has := func(offset int, magic string) bool {
+		return len(b) >= offset+len(magic) &&
+			string(b[offset:offset+len(magic)]) == magic
+	}
+	switch {
+	case has(0, "ID3"):
+		return MP3, true
+	case has(0, "OggS") && bytes.Contains(b, []byte("OpusHead")):
+		return OPUS, true
+	case has(0, "OggS"):
+		return OGG, true
+	case has(0, "fLaC"):
+		return FLAC, true
+	case has(0, "RIFF") && has(8, "WAVE"):
+		return WAV, true
+	case has(0, "\x1A\x45\xDF\xA3"):
+		return WEBM, true
+	case has(0, "#EXTM3U"):
+		return HLS, true
+	case has(0, "%PDF-"):
+		return PDF, true
+	case has(0, "PK\x03\x04") && has(30, "mimetypeapplication/epub+zip"):
+		return EPUB, true
+	case has(4, "ftyp"):
+		switch {
+		case has(8, "M4A "):
+			return M4A, true
+		case has(8, "M4B "):
+			return M4B, true
+		case has(8, "M4V"):
+			return M4V, true
+		case has(8, "qt  "):
+			return MOV, true
+		}
+		return MP4, true
+	case len(b) >= 2 && b[0] == 0xFF && b[1]&0xF6 == 0xF0:
+		return AAC, true // ADTS has no layer
+	}
+	if _, ok := parseMP3Frame(b); ok {
+		return MP3, true
+	}
+	return enclosureUnknown, false
+}
+
+// parseEnclosureType returns the EnclosureType of the MIME type, or an
+// unknown EnclosureType that formats as "application/octet-stream".
+var parseEnclosureType = func(mime string) EnclosureType {
+	enclosureMu.RLock()
+	defer enclosureMu.RUnlock()
+	for et, r := range enclosureTypes {
+		if strings.EqualFold(r.mime, mime) {
+			return EnclosureType(et)
+		}
 	}
-	return enclosureDefault
+	return enclosureUnknown
 }
 
 // Enclosure represents a download enclosure.
@@ -1,6 +1,7 @@
 package podcast_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/eduncan911/podcast"
@@ -19,7 +20,16 @@ var enclosureTests = []enclosureTest{
 	{podcast.MP3, "audio/mpeg"},
 	{podcast.MOV, "video/quicktime"},
 	{podcast.PDF, "application/pdf"},
-	{podcast.EPUB, "document/x-epub"},
+	{podcast.EPUB, "application/epub+zip"},
+	{podcast.OPUS, "audio/opus"},
+	{podcast.OGG, "audio/ogg"},
+	{podcast.FLAC, "audio/flac"},
+	{podcast.AAC, "audio/aac"},
+	{podcast.WEBM, "video/webm"},
+	{podcast.WAV, "audio/wav"},
+	{podcast.HLS, "application/x-mpegURL"},
+	{podcast.M4B, "audio/x-m4b"},
+	{podcast.EPUBLegacy, "document/x-epub"},
 	{podcast.M4A, "audio/x-m4a"},
 	{99, "application/octet-stream"},
 }
@@ -35,3 +45,86 @@ func TestEnclosureTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestRegisterEnclosureType is not parallel, as it changes the registry
+// that the other tests read.
+func TestRegisterEnclosureType(t *testing.T) {
+	// act
+	et := podcast.RegisterEnclosureType("audio/x-matroska", ".MKA")
+	again := podcast.RegisterEnclosureType("AUDIO/X-MATROSKA", ".mk4")
+	builtin := podcast.RegisterEnclosureType("audio/mpeg", ".mp2")
+
+	// assert
+	assert.EqualValues(t, "audio/x-matroska", et.String())
+	assert.EqualValues(t, et, again)
+	assert.EqualValues(t, podcast.MP3, builtin)
+	for _, ext := range []string{".mka", ".MK4"} {
+		got, ok := podcast.EnclosureTypeByExtension(ext)
+		assert.True(t, ok, ext)
+		assert.EqualValues(t, et, got, ext)
+	}
+	got, ok := podcast.EnclosureTypeByExtension(".mp2")
+	assert.True(t, ok)
+	assert.EqualValues(t, podcast.MP3, got)
+}
+
+func TestEnclosureTypeByExtension(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := map[string]podcast.EnclosureType{
+		".mp3":  podcast.MP3,
+		".M4B":  podcast.M4B,
+		".oga":  podcast.OGG,
+		".m3u8": podcast.HLS,
+		".epub": podcast.EPUB,
+	}
+	for ext, want := range tests {
+		// act
+		got, ok := podcast.EnclosureTypeByExtension(ext)
+
+		// assert
+		assert.True(t, ok, ext)
+		assert.EqualValues(t, want, got, ext)
+	}
+	_, ok := podcast.EnclosureTypeByExtension(".txt")
+	assert.False(t, ok)
+}
+
+func TestEnclosureTypeByContent(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	ogg := "OggS" + strings.Repeat("\x00", 24)
+	tests := []struct {
+		head string
+		want podcast.EnclosureType
+	}{
+		{"ID3\x04\x00", podcast.MP3},
+		{"\xFF\xFB\x90\x00", podcast.MP3},
+		{"\xFF\xF1\x50\x80", podcast.AAC},
+		{ogg + "OpusHead", podcast.OPUS},
+		{ogg + "\x01vorbis", podcast.OGG},
+		{"fLaC\x00", podcast.FLAC},
+		{"RIFF\x24\x00\x00\x00WAVEfmt ", podcast.WAV},
+		{"\x1A\x45\xDF\xA3\x01", podcast.WEBM},
+		{"#EXTM3U\n#EXT-X-VERSION:3", podcast.HLS},
+		{"%PDF-1.7", podcast.PDF},
+		{"PK\x03\x04" + strings.Repeat("\x00", 26) + "mimetypeapplication/epub+zip", podcast.EPUB},
+		{"\x00\x00\x00\x20ftypM4A \x00", podcast.M4A},
+		{"\x00\x00\x00\x20ftypM4B \x00", podcast.M4B},
+		{"\x00\x00\x00\x20ftypM4V \x00", podcast.M4V},
+		{"\x00\x00\x00\x14ftypqt  \x00", podcast.MOV},
+		{"\x00\x00\x00\x20ftypisom\x00", podcast.MP4},
+	}
+	for _, tt := range tests {
+		// act
+		got, ok := podcast.EnclosureTypeByContent([]byte(tt.head))
+
+		// assert
+		assert.True(t, ok, "%q", tt.head)
+		assert.EqualValues(t, tt.want, got, "%q", tt.head)
+	}
+	_, ok := podcast.EnclosureTypeByContent([]byte("plain text"))
+	assert.False(t, ok)
+}
@@ -2,10 +2,12 @@ package podcast_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/eduncan911/podcast"
 )
@@ -73,7 +75,7 @@ func Example_httpHandlers() {
 	os.Stdout.Write(rr.Body.Bytes())
 	// Output:
 	// <?xml version="1.0" encoding="UTF-8"?>
-	// <rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+	// <rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
 	//   <channel>
 	//     <title>eduncan911 Podcasts</title>
 	//     <link>http://eduncan911.com/</link>
@@ -93,6 +95,7 @@ func Example_httpHandlers() {
 	//     <itunes:summary><![CDATA[link <a href="http://example.com">example.com</a>]]></itunes:summary>
 	//     <itunes:image href="http://janedoe.com/i.jpg"></itunes:image>
 	//     <itunes:explicit>no</itunes:explicit>
+	//     <podcast:guid>f89341c8-bd3f-5b62-8175-5ad91dbbc107</podcast:guid>
 	//     <item>
 	//       <guid>http://e.com/1.mp3</guid>
 	//       <title>Episode 1</title>
@@ -165,7 +168,7 @@ func Example_ioWriter() {
 
 	// Output:
 	// <?xml version="1.0" encoding="UTF-8"?>
-	// <rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+	// <rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
 	//   <channel>
 	//     <title>Sample Podcasts</title>
 	//     <link>http://example.com/</link>
@@ -185,6 +188,7 @@ func Example_ioWriter() {
 	//     <itunes:subtitle>A simple Podcast</itunes:subtitle>
 	//     <itunes:summary><![CDATA[link <a href="http://example.com">example.com</a>]]></itunes:summary>
 	//     <itunes:image href="http://example.com/podcast.jpg"></itunes:image>
+	//     <podcast:guid>4593ab08-d29c-5a72-b951-911e85c717ea</podcast:guid>
 	//     <item>
 	//       <guid>http://example.com/9.mp3</guid>
 	//       <title>Episode 9</title>
@@ -212,3 +216,79 @@ func Example_ioWriter() {
 	//   </channel>
 	// </rss>
 }
+
+func ExampleHandler() {
+	// serve the feed with caching headers and conditional GETs
+	h := podcast.NewHandler(func(r *http.Request) (*podcast.Podcast, error) {
+		p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+		return &p, nil
+	})
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest("GET", "/feed.rss", nil))
+	fmt.Println(rr.Code, rr.Header().Get("Last-Modified"))
+
+	// polling again with the ETag returns no body
+	r := httptest.NewRequest("GET", "/feed.rss", nil)
+	r.Header.Set("If-None-Match", rr.Header().Get("ETag"))
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, r)
+	fmt.Println(rr.Code, rr.Body.Len())
+	// Output:
+	// 200 Mon, 06 Feb 2017 08:21:52 GMT
+	// 304 0
+}
+
+func ExamplePublisher() {
+	// a WebSub hub that aggregators have subscribed to the feed with
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.FormValue("hub.mode"), r.FormValue("hub.url"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hub.Close()
+
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://example.com/feed.rss")
+	p.AddHub(hub.URL)
+
+	// notify the hubs once the new episode has been encoded
+	if err := p.Encode(ioutil.Discard); err != nil {
+		fmt.Println(err)
+	}
+	pub := podcast.NewPublisher()
+	pub.Client = hub.Client()
+	if err := pub.Publish(&p); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// publish http://example.com/feed.rss
+}
+
+func ExamplePodcast_MigrateTo() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://old.example.com/feed.rss")
+	if err := p.AddLocked(true, "owner@example.com"); err != nil {
+		fmt.Println(err)
+	}
+
+	warnings, err := p.MigrateTo("https://new.example.com/feed.rss")
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+
+	// the old host redirects apps to the new feed for 90 days
+	h := podcast.NewRedirect("/feed.rss", p.INewFeedURL,
+		pubDate.Add(90*24*time.Hour), podcast.NewHandler(func(r *http.Request) (*podcast.Podcast, error) {
+			return &p, nil
+		}))
+	h.Now = func() time.Time { return pubDate }
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest("GET", "/feed.rss", nil))
+	fmt.Println(rr.Code, rr.Header().Get("Location"))
+	// Output:
+	// warning PLocked: PLocked is yes, so the new host may refuse to import the feed until it is set to no (podcasting-channel-locked-migration)
+	// 301 https://new.example.com/feed.rss
+}
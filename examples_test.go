@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eduncan911/podcast"
 )
@@ -265,3 +267,868 @@ func ExampleItem_AddDuration() {
 	// Output:
 	// 8:53
 }
+
+func ExampleDecode() {
+	feed := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>eduncan911 Podcasts</title>
+    <link>http://eduncan911.com/</link>
+    <description>An example Podcast</description>
+    <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+    <itunes:summary><![CDATA[A <b>rich</b> summary]]></itunes:summary>
+    <itunes:owner>
+      <itunes:name>Jane Doe</itunes:name>
+      <itunes:email>me@janedoe.com</itunes:email>
+    </itunes:owner>
+    <itunes:category text="Technology">
+      <itunes:category text="Podcasting"></itunes:category>
+    </itunes:category>
+    <item>
+      <guid>http://e.com/1.mp3</guid>
+      <title>Episode 1</title>
+      <link>http://example.com/1.mp3</link>
+      <description>Description for Episode 1</description>
+      <author>me@janedoe.com (Jane Doe)</author>
+      <pubDate>Sun, 05 Feb 2017 08:21:52 +0000</pubDate>
+      <enclosure url="http://e.com/1.mp3" length="110" type="audio/mpeg"></enclosure>
+    </item>
+  </channel>
+</rss>`
+
+	// decode the feed back into a Podcast
+	p, err := podcast.Decode(strings.NewReader(feed))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(p.Title, "-", p.PubDate)
+	fmt.Println(p.ISummary.Text)
+	fmt.Println(p.IOwner.Name, p.IOwner.Email)
+	fmt.Println(p.ICategories[0].Text, p.ICategories[0].ICategories[0].Text)
+	for _, i := range p.Items {
+		fmt.Println(i.Title, "-", i.PubDate.UTC(), "-", i.Author.Name)
+		fmt.Println(i.Enclosure.Length, i.Enclosure.Type == podcast.MP3)
+	}
+	// Output:
+	// eduncan911 Podcasts - Sat, 04 Feb 2017 08:21:52 +0000
+	// A <b>rich</b> summary
+	// Jane Doe me@janedoe.com
+	// Technology Podcasting
+	// Episode 1 - 2017-02-05 08:21:52 +0000 UTC - Jane Doe
+	// 110 true
+}
+
+func ExamplePodcast_AddLocked() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+
+	// lock the feed against imports by other platforms
+	if err := p.AddLocked(true, "me@janedoe.com"); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(p.PLocked.Text, p.PLocked.Owner)
+	// Output:
+	// yes me@janedoe.com
+}
+
+func ExamplePodcast_AddFunding() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+
+	// add the funding links
+	if err := p.AddFunding("https://example.com/donate", "Support the show!"); err != nil {
+		fmt.Println(err)
+	}
+	if err := p.AddFunding("https://example.com/member", "Become a member"); err != nil {
+		fmt.Println(err)
+	}
+
+	for _, f := range p.PFunding {
+		fmt.Println(f.URL, f.Text)
+	}
+	// Output:
+	// https://example.com/donate Support the show!
+	// https://example.com/member Become a member
+}
+
+func ExampleItem_AddTranscript() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	i := podcast.Item{
+		Title:       "item title",
+		Description: "item desc",
+		Link:        "http://example.com/1",
+		PubDate:     &pubDate,
+	}
+
+	// add a transcript and closed captions
+	if err := i.AddTranscript("http://example.com/1.html", "text/html", "", ""); err != nil {
+		fmt.Println(err)
+	}
+	if err := i.AddTranscript("http://example.com/1.vtt", "text/vtt", "en", "captions"); err != nil {
+		fmt.Println(err)
+	}
+	if _, err := p.AddItem(i); err != nil {
+		fmt.Println(err)
+	}
+
+	os.Stdout.Write(p.Bytes())
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>link</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <item>
+	//       <guid>http://example.com/1</guid>
+	//       <title>item title</title>
+	//       <link>http://example.com/1</link>
+	//       <description>item desc</description>
+	//       <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//       <podcast:transcript url="http://example.com/1.html" type="text/html"></podcast:transcript>
+	//       <podcast:transcript url="http://example.com/1.vtt" type="text/vtt" language="en" rel="captions"></podcast:transcript>
+	//     </item>
+	//   </channel>
+	// </rss>
+}
+
+func ExampleItem_AddChapters() {
+	i := podcast.Item{
+		Title:       "item title",
+		Description: "item desc",
+		Link:        "http://example.com/1",
+	}
+
+	// add the chapters file
+	if err := i.AddChapters("http://example.com/1.json", "application/json+chapters"); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(i.PChapters.URL, i.PChapters.Type)
+	// Output:
+	// http://example.com/1.json application/json+chapters
+}
+
+func ExamplePodcast_AddValue() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+
+	// stream sats to the hosts, less a 1% fee for the app
+	err := p.AddValue("lightning", "keysend", []podcast.PValueRecipient{
+		{Name: "Host", Type: "node", Address: "02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52", Split: 90},
+		{Name: "Producer", Type: "node", Address: "032f4ffbbafffbe51726ad3c164a3d0d37ec27bc67b29a159b0f49ae8ac21b8508", Split: 10},
+		{Name: "App", Type: "node", Address: "03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a", Split: 1, Fee: true},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	os.Stdout.Write(p.Bytes())
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>link</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <podcast:value type="lightning" method="keysend">
+	//       <podcast:valueRecipient name="Host" type="node" address="02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52" split="90"></podcast:valueRecipient>
+	//       <podcast:valueRecipient name="Producer" type="node" address="032f4ffbbafffbe51726ad3c164a3d0d37ec27bc67b29a159b0f49ae8ac21b8508" split="10"></podcast:valueRecipient>
+	//       <podcast:valueRecipient name="App" type="node" address="03ae9f91a0cb8ff43840e3c322c4c61f019d8c1c3cea15a25cfc425ac605e61a4a" split="1" fee="true"></podcast:valueRecipient>
+	//     </podcast:value>
+	//   </channel>
+	// </rss>
+}
+
+func ExampleChapters_Encode() {
+	c := podcast.Chapters{Title: "episode title"}
+
+	// add the chapters, hiding the sponsor from the table of contents
+	toc := false
+	if err := c.AddChapter(0, "Intro"); err != nil {
+		fmt.Println(err)
+	}
+	if err := c.AddChapter(135, "Sponsor"); err != nil {
+		fmt.Println(err)
+	}
+	c.Chapters[1].TOC = &toc
+	c.Chapters[1].URL = "http://example.com/sponsor"
+
+	if err := c.Encode(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   "version": "1.2.0",
+	//   "title": "episode title",
+	//   "chapters": [
+	//     {
+	//       "startTime": 0,
+	//       "title": "Intro"
+	//     },
+	//     {
+	//       "startTime": 135,
+	//       "title": "Sponsor",
+	//       "url": "http://example.com/sponsor",
+	//       "toc": false
+	//     }
+	//   ]
+	// }
+}
+
+func ExampleParseChapters() {
+	notes := `In this episode we talk about the news.
+
+00:00 Intro
+02:15 - News of the week
+1:05:30 Listener questions`
+
+	c, err := podcast.ParseChapters(strings.NewReader(notes))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, ch := range c.Chapters {
+		fmt.Println(ch.StartTime, ch.Title)
+	}
+	// Output:
+	// 0 Intro
+	// 135 News of the week
+	// 3930 Listener questions
+}
+
+func ExampleItem_AddJSONChapters() {
+	i := podcast.Item{
+		Title:       "item title",
+		Description: "item desc",
+		Link:        "http://example.com/1",
+	}
+
+	// link the file written by Chapters.Encode
+	if err := i.AddJSONChapters("http://example.com/1.json"); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(i.PChapters.URL, i.PChapters.Type)
+	// Output:
+	// http://example.com/1.json application/json+chapters
+}
+
+func ExampleDecodeTranscript() {
+	srt := `1
+00:00:00,500 --> 00:00:02,000
+Welcome to the show.
+
+2
+00:00:02,250 --> 00:00:04,000
+Thanks for having me.
+`
+
+	// convert the SRT captions into a WebVTT file
+	t, err := podcast.DecodeTranscript(strings.NewReader(srt), podcast.TranscriptSRT)
+	if err != nil {
+		fmt.Println(err)
+	}
+	t.Cues[0].Speaker = "Host"
+	t.Cues[1].Speaker = "Guest"
+
+	if err := t.Encode(os.Stdout, podcast.TranscriptVTT); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// WEBVTT
+	//
+	// 00:00:00.500 --> 00:00:02.000
+	// <v Host>Welcome to the show.
+	//
+	// 00:00:02.250 --> 00:00:04.000
+	// <v Guest>Thanks for having me.
+}
+
+func ExampleTranscript_Encode() {
+	t := podcast.Transcript{Cues: []*podcast.Cue{
+		{Start: 0, End: 1500 * time.Millisecond, Speaker: "Host", Text: "Welcome to the show."},
+		{Start: 65 * time.Second, End: 67 * time.Second, Speaker: "Guest", Text: "Thanks & hello."},
+	}}
+
+	// write the Podcast Index JSON and HTML transcripts
+	if err := t.Encode(os.Stdout, podcast.TranscriptJSON); err != nil {
+		fmt.Println(err)
+	}
+	if err := t.Encode(os.Stdout, podcast.TranscriptHTML); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   "version": "1.0.0",
+	//   "segments": [
+	//     {
+	//       "speaker": "Host",
+	//       "startTime": 0,
+	//       "endTime": 1.5,
+	//       "body": "Welcome to the show."
+	//     },
+	//     {
+	//       "speaker": "Guest",
+	//       "startTime": 65,
+	//       "endTime": 67,
+	//       "body": "Thanks & hello."
+	//     }
+	//   ]
+	// }
+	// <cite>Host:</cite>
+	// <time>0:00</time>
+	// <p>Welcome to the show.</p>
+	// <cite>Guest:</cite>
+	// <time>1:05</time>
+	// <p>Thanks &amp; hello.</p>
+}
+
+func ExamplePodcast_AddShowType() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+
+	// present the show oldest episode first
+	p.AddShowType(podcast.ShowSerial)
+
+	fmt.Println(p.IType)
+	// Output:
+	// serial
+}
+
+func ExampleItem_AddSeasonEpisode() {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddShowType(podcast.ShowSerial)
+	i := podcast.Item{
+		Title:       "S2E3 The Return",
+		ITitle:      "The Return",
+		Description: "item desc",
+		Link:        "http://example.com/2/3",
+		PubDate:     &pubDate,
+	}
+
+	// add the season and episode numbers, required for serial shows
+	i.AddSeasonEpisode(2, 3)
+	i.AddEpisodeType(podcast.EpisodeFull)
+
+	if _, err := p.AddItem(i); err != nil {
+		fmt.Println(err)
+	}
+
+	os.Stdout.Write(p.Bytes())
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>link</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <itunes:type>serial</itunes:type>
+	//     <item>
+	//       <guid>http://example.com/2/3</guid>
+	//       <title>S2E3 The Return</title>
+	//       <link>http://example.com/2/3</link>
+	//       <description>item desc</description>
+	//       <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//       <itunes:title>The Return</itunes:title>
+	//       <itunes:episodeType>full</itunes:episodeType>
+	//       <itunes:season>2</itunes:season>
+	//       <itunes:episode>3</itunes:episode>
+	//     </item>
+	//   </channel>
+	// </rss>
+}
+
+func ExampleItem_AddEpisodeType() {
+	i := podcast.Item{
+		Title:       "Coming Soon",
+		Description: "item desc",
+		Link:        "http://example.com/trailer",
+	}
+
+	// mark the episode as a trailer
+	i.AddEpisodeType(podcast.EpisodeTrailer)
+
+	fmt.Println(i.IEpisodeType)
+	// Output:
+	// trailer
+}
+
+func ExamplePodcast_AddAppleCategory() {
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// add the Category, validated against the Apple taxonomy
+	err := p.AddAppleCategory(podcast.CategoryTechnology, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+	err = p.AddAppleCategory(podcast.CategoryNews, []podcast.AppleCategory{
+		podcast.CategoryNewsTechNews,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// a typo or mismatched subcategory is rejected
+	err = p.AddAppleCategory(podcast.CategoryArts, []podcast.AppleCategory{
+		podcast.CategoryNewsTechNews,
+	})
+	fmt.Println(err)
+
+	fmt.Println(p.Category)
+	// Output:
+	// Arts: Tech News is not an Apple Podcasts subcategory of Arts
+	// Technology,News
+}
+
+func ExampleModernAppleCategory() {
+	// map a category retired by Apple in 2019 to its replacement
+	c, sub, ok := podcast.ModernAppleCategory("Science & Medicine", "Medicine")
+	fmt.Println(c, "/", sub, ok)
+
+	c, sub, ok = podcast.ModernAppleCategory("Games & Hobbies", "")
+	fmt.Println(c, "/", sub, ok)
+
+	// valid categories are returned as-is
+	c, sub, ok = podcast.ModernAppleCategory("Technology", "")
+	fmt.Println(c, "/", sub, ok)
+	// Output:
+	// Health & Fitness / Medicine true
+	// Leisure /  true
+	// Technology /  true
+}
+
+func ExampleAppleCategory_Subcategories() {
+	for _, sub := range podcast.CategoryFiction.Subcategories() {
+		fmt.Println(sub)
+	}
+	// Output:
+	// Comedy Fiction
+	// Drama
+	// Science Fiction
+}
+
+func ExamplePodcast_Validate() {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	p.AddAuthor("Jane Doe", "me@janedoe.com")
+	p.AddImage("http://example.com/image.gif")
+	p.IOwner = &podcast.Author{Name: "Jane Doe", Email: "me@janedoe.com"}
+	p.IExplicit = "false"
+	if err := p.AddAppleCategory(podcast.CategoryTechnology, nil); err != nil {
+		fmt.Println(err)
+	}
+
+	i := podcast.Item{
+		Title:       "Episode 1",
+		Description: "Description for Episode 1",
+		PubDate:     &pubDate,
+	}
+	i.AddEnclosure("http://example.com/1.mp3", podcast.MP3, 0)
+	if _, err := p.AddItem(i); err != nil {
+		fmt.Println(err)
+	}
+
+	// validate the whole feed against the Apple Podcasts rules
+	r := p.Validate(podcast.ProfileApple)
+	for _, f := range r.Findings {
+		fmt.Println(f)
+	}
+	fmt.Println("errors:", r.HasErrors())
+	// Output:
+	// warning IImage.HREF: IImage should be a .jpg or .png file (apple-channel-image-type)
+	// warning Items[0].Enclosure.Length: Enclosure.Length should be set (apple-item-enclosure-length)
+	// warning Items[0].IDuration: IDuration is recommended (apple-item-duration)
+	// errors: false
+}
+
+func ExampleWithClock() {
+	// a fixed clock makes the output byte-identical on every run
+	clock := func() time.Time { return createdDate }
+	p := podcast.New("title", "link", "description", nil, nil,
+		podcast.WithClock(clock))
+
+	// an Item without a PubDate is dated with the same clock
+	i := podcast.Item{
+		Title:       "item title",
+		Description: "item desc",
+		Link:        "http://example.com/1",
+	}
+	if _, err := p.AddItem(i); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(p.PubDate)
+	fmt.Println(p.LastBuildDate)
+	fmt.Println(p.Items[0].PubDateFormatted)
+	// Output:
+	// Wed, 01 Feb 2017 08:21:52 +0000
+	// Wed, 01 Feb 2017 08:21:52 +0000
+	// Wed, 01 Feb 2017 08:21:52 +0000
+}
+
+func ExampleWithStrictDates() {
+	p := podcast.New("title", "link", "description", &pubDate, nil,
+		podcast.WithStrictDates())
+
+	// an Item without a PubDate is rejected instead of dated now
+	i := podcast.Item{
+		Title:       "item title",
+		Description: "item desc",
+		Link:        "http://example.com/1",
+	}
+	_, err := p.AddItem(i)
+
+	fmt.Println(p.PubDate)
+	fmt.Printf("%q\n", p.LastBuildDate)
+	fmt.Println(err)
+	// Output:
+	// Sat, 04 Feb 2017 08:21:52 +0000
+	// ""
+	// item title: PubDate is required
+}
+
+func ExamplePodcast_EncodeAtom() {
+	p := podcast.New(
+		"eduncan911 Podcasts",
+		"http://eduncan911.com/",
+		"An example Podcast",
+		&pubDate, &updatedDate,
+	)
+	p.AddAuthor("Jane Doe", "me@janedoe.com")
+	p.AddAtomLink("http://eduncan911.com/feed.rss")
+	p.AddImage("http://janedoe.com/i.jpg")
+
+	item := podcast.Item{
+		Title:       "Episode 1",
+		Link:        "http://eduncan911.com/1",
+		Description: "Description for Episode 1",
+		PubDate:     &pubDate,
+	}
+	item.AddEnclosure("http://e.com/1.mp3", podcast.MP3, 183)
+	if _, err := p.AddItem(item); err != nil {
+		fmt.Println(err)
+	}
+
+	// write the same Podcast as an Atom 1.0 feed
+	if err := p.EncodeAtom(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <feed xmlns="http://www.w3.org/2005/Atom">
+	//   <id>http://eduncan911.com/feed.rss</id>
+	//   <title>eduncan911 Podcasts</title>
+	//   <subtitle>An example Podcast</subtitle>
+	//   <updated>2017-02-06T08:21:52Z</updated>
+	//   <link href="http://eduncan911.com/" rel="alternate"></link>
+	//   <author>
+	//     <name>Jane Doe</name>
+	//     <email>me@janedoe.com</email>
+	//   </author>
+	//   <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//   <logo>http://janedoe.com/i.jpg</logo>
+	//   <entry>
+	//     <id>http://e.com/1.mp3</id>
+	//     <title>Episode 1</title>
+	//     <updated>2017-02-04T08:21:52Z</updated>
+	//     <published>2017-02-04T08:21:52Z</published>
+	//     <link href="http://eduncan911.com/1" rel="alternate"></link>
+	//     <link href="http://e.com/1.mp3" rel="enclosure" type="audio/mpeg" length="183"></link>
+	//     <author>
+	//       <name>Jane Doe</name>
+	//       <email>me@janedoe.com</email>
+	//     </author>
+	//     <summary type="html">Description for Episode 1</summary>
+	//   </entry>
+	// </feed>
+}
+
+func ExamplePodcast_EncodeJSONFeed() {
+	p := podcast.New(
+		"eduncan911 Podcasts",
+		"http://eduncan911.com/",
+		"An example Podcast",
+		&pubDate, &updatedDate,
+	)
+	p.AddAuthor("Jane Doe", "me@janedoe.com")
+	p.AddAtomLink("http://eduncan911.com/feed.rss")
+	p.AddImage("http://janedoe.com/i.jpg")
+	p.IExplicit = "false"
+
+	item := podcast.Item{
+		Title:       "Episode 1",
+		Link:        "http://eduncan911.com/1",
+		Description: "Description for <b>Episode 1</b>",
+		PubDate:     &pubDate,
+	}
+	item.AddEnclosure("http://e.com/1.mp3", podcast.MP3, 183)
+	item.AddDuration(3661)
+	item.AddSeasonEpisode(1, 1)
+	if _, err := p.AddItem(item); err != nil {
+		fmt.Println(err)
+	}
+
+	// write the same Podcast as a JSON Feed 1.1
+	if err := p.EncodeJSONFeed(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   "version": "https://jsonfeed.org/version/1.1",
+	//   "title": "eduncan911 Podcasts",
+	//   "home_page_url": "http://eduncan911.com/",
+	//   "feed_url": "http://eduncan911.com/feed.rss",
+	//   "description": "An example Podcast",
+	//   "icon": "http://janedoe.com/i.jpg",
+	//   "authors": [
+	//     {
+	//       "name": "Jane Doe"
+	//     }
+	//   ],
+	//   "language": "en-us",
+	//   "_itunes": {
+	//     "about": "https://help.apple.com/itc/podcasts_connect/#/itcb54353390",
+	//     "author": "me@janedoe.com (Jane Doe)",
+	//     "explicit": "false"
+	//   },
+	//   "items": [
+	//     {
+	//       "id": "http://e.com/1.mp3",
+	//       "url": "http://eduncan911.com/1",
+	//       "title": "Episode 1",
+	//       "content_html": "Description for <b>Episode 1</b>",
+	//       "image": "http://janedoe.com/i.jpg",
+	//       "date_published": "2017-02-04T08:21:52Z",
+	//       "authors": [
+	//         {
+	//           "name": "Jane Doe"
+	//         }
+	//       ],
+	//       "attachments": [
+	//         {
+	//           "url": "http://e.com/1.mp3",
+	//           "mime_type": "audio/mpeg",
+	//           "size_in_bytes": 183,
+	//           "duration_in_seconds": 3661
+	//         }
+	//       ],
+	//       "_itunes": {
+	//         "about": "https://help.apple.com/itc/podcasts_connect/#/itcb54353390",
+	//         "author": "me@janedoe.com (Jane Doe)",
+	//         "duration": "1:01:01",
+	//         "season": 1,
+	//         "episode": 1
+	//       }
+	//     }
+	//   ]
+	// }
+}
+
+func ExampleRegisterEnclosureType() {
+	// register a type missing from the built-in list
+	mka := podcast.RegisterEnclosureType("audio/x-matroska", ".mka")
+
+	i := podcast.Item{}
+	i.AddEnclosure("http://example.com/1.mka", mka, 183)
+	fmt.Println(i.Enclosure.Type)
+
+	// infer the type of other files by extension or content
+	et, _ := podcast.EnclosureTypeByExtension(".m4b")
+	fmt.Println(et)
+	et, _ = podcast.EnclosureTypeByContent([]byte("fLaC\x00\x00\x00\x22"))
+	fmt.Println(et)
+	// Output:
+	// audio/x-matroska
+	// audio/x-m4b
+	// audio/flac
+}
+
+func ExamplePodcast_Archives() {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://example.com/feed.rss")
+	for n := 1; n <= 3; n++ {
+		d := pubDate.AddDate(0, 0, n)
+		item := podcast.Item{Title: fmt.Sprint("Episode ", n), Description: "desc", PubDate: &d}
+		item.AddEnclosure(fmt.Sprint("http://example.com/", n, ".mp3"), podcast.MP3, 183)
+		if _, err := p.AddItem(item); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	// keep the newest episode in the feed and archive the others
+	_, archives := p.Archives(2, func(n int) string {
+		return fmt.Sprint("http://example.com/archive/", n, ".rss")
+	})
+
+	archives[0].Items = archives[0].Items[:1] // shortened for the example
+	os.Stdout.Write(archives[0].Bytes())
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0" xmlns:fh="http://purl.org/syndication/history/1.0">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>http://example.com/</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <atom:link href="http://example.com/archive/1.rss" rel="self" type="application/rss+xml"></atom:link>
+	//     <atom:link href="http://example.com/feed.rss" rel="current" type="application/rss+xml"></atom:link>
+	//     <fh:archive></fh:archive>
+	//     <podcast:guid>3a5c7c9c-fe16-55bf-a06e-e5b41d65e0ea</podcast:guid>
+	//     <item>
+	//       <guid>http://example.com/2.mp3</guid>
+	//       <title>Episode 2</title>
+	//       <link>http://example.com/2.mp3</link>
+	//       <description>desc</description>
+	//       <pubDate>Mon, 06 Feb 2017 08:21:52 +0000</pubDate>
+	//       <enclosure url="http://example.com/2.mp3" length="183" type="audio/mpeg"></enclosure>
+	//     </item>
+	//   </channel>
+	// </rss>
+}
+
+func ExampleNewFeedWriter() {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+
+	// items are written one at a time, such as from a database cursor
+	fw := podcast.NewFeedWriter(os.Stdout, &p)
+	for n := 1; n <= 2; n++ {
+		item := podcast.Item{Title: fmt.Sprint("Episode ", n), Description: "desc", PubDate: &pubDate}
+		item.AddEnclosure(fmt.Sprint("http://example.com/", n, ".mp3"), podcast.MP3, 183)
+		if err := fw.WriteItem(item); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if err := fw.Close(); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>http://example.com/</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <item>
+	//       <guid>http://example.com/1.mp3</guid>
+	//       <title>Episode 1</title>
+	//       <link>http://example.com/1.mp3</link>
+	//       <description>desc</description>
+	//       <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//       <enclosure url="http://example.com/1.mp3" length="183" type="audio/mpeg"></enclosure>
+	//     </item>
+	//     <item>
+	//       <guid>http://example.com/2.mp3</guid>
+	//       <title>Episode 2</title>
+	//       <link>http://example.com/2.mp3</link>
+	//       <description>desc</description>
+	//       <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//       <enclosure url="http://example.com/2.mp3" length="183" type="audio/mpeg"></enclosure>
+	//     </item>
+	//   </channel>
+	// </rss>
+}
+
+func ExamplePodcast_AddSkipHours() {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+
+	// aggregators may skip the feed overnight and on weekends
+	if err := p.AddSkipHours(0, 1, 2, 3, 4, 5); err != nil {
+		fmt.Println(err)
+	}
+	if err := p.AddSkipDays(time.Saturday, time.Sunday); err != nil {
+		fmt.Println(err)
+	}
+	if err := p.AddCloud("rpc.example.com", 80, "/RPC2", "xmlStorageSystem.rssPleaseNotify", "xml-rpc"); err != nil {
+		fmt.Println(err)
+	}
+
+	os.Stdout.Write(p.Bytes())
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+	//   <channel>
+	//     <title>title</title>
+	//     <link>http://example.com/</link>
+	//     <description>description</description>
+	//     <generator>go podcast v1.3.1 (github.com/eduncan911/podcast)</generator>
+	//     <language>en-us</language>
+	//     <lastBuildDate>Mon, 06 Feb 2017 08:21:52 +0000</lastBuildDate>
+	//     <pubDate>Sat, 04 Feb 2017 08:21:52 +0000</pubDate>
+	//     <cloud domain="rpc.example.com" port="80" path="/RPC2" registerProcedure="xmlStorageSystem.rssPleaseNotify" protocol="xml-rpc"></cloud>
+	//     <skipHours>
+	//       <hour>0</hour>
+	//       <hour>1</hour>
+	//       <hour>2</hour>
+	//       <hour>3</hour>
+	//       <hour>4</hour>
+	//       <hour>5</hour>
+	//     </skipHours>
+	//     <skipDays>
+	//       <day>Saturday</day>
+	//       <day>Sunday</day>
+	//     </skipDays>
+	//   </channel>
+	// </rss>
+}
+
+func ExampleDiff() {
+	feed := func(guid string) *podcast.Podcast {
+		p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+		item := podcast.Item{GUID: guid, Title: "Episode 1", Description: "desc", PubDate: &pubDate}
+		item.AddEnclosure("http://example.com/1.mp3", podcast.MP3, 183)
+		if _, err := p.AddItem(item); err != nil {
+			fmt.Println(err)
+		}
+		return &p
+	}
+	yesterday, today := feed("1"), feed("http://example.com/1.mp3")
+	today.Description = "new description"
+
+	for _, c := range podcast.Diff(yesterday, today) {
+		fmt.Println(c)
+	}
+	// Output:
+	// modified Description: "description" => "new description"
+	// guid of item "Episode 1" changed: "1" => "http://example.com/1.mp3"
+}
+
+func ExampleWithStrictGUIDs() {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate,
+		podcast.WithStrictGUIDs())
+	p.AddAtomLink("https://mp3s.nashownotes.com/pc20rss.xml")
+
+	item := podcast.Item{Title: "Episode 1", Description: "desc", PubDate: &pubDate}
+	item.AddEnclosure("http://example.com/1.mp3", podcast.MP3, 183)
+	if _, err := p.AddItem(item); err != nil {
+		fmt.Println(err)
+	}
+	item.GUID = "episode-1"
+	if _, err := p.AddItem(item); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(podcast.PodcastGUID(p.AtomLink.HREF))
+	fmt.Println(p.Items[0].GUIDFormatted.Value, p.Items[0].GUIDFormatted.IsPermaLink)
+	// Output:
+	// Episode 1: GUID is required instead of being derived from URLs
+	// 917393e3-1b1e-5cef-ace4-edaa54e1f810
+	// episode-1 false
+}
@@ -0,0 +1,98 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// FeedWriter streams the RSS feed of a Podcast one Item at a time, such
+// as from a database cursor, so that feeds of thousands of episodes are
+// written with constant memory instead of holding every Item for Encode.
+//
+// The channel is written by the first WriteItem, or by Close when there
+// are no Items, along with any Items already added to it.  The podcast
+// namespace is always declared as the Items to come are not known yet.
+type FeedWriter struct {
+	w       io.Writer
+	p       *Podcast
+	footer  []byte
+	started bool
+	closed  bool
+}
+
+// NewFeedWriter returns a FeedWriter writing the channel p, and then each
+// Item passed to WriteItem, to w.
+func NewFeedWriter(w io.Writer, p *Podcast) *FeedWriter {
+	return &FeedWriter{w: w, p: p}
+}
+
+// WriteItem validates the Item and sets its defaults the same as
+// Podcast.AddItem, then writes it to the feed.  The Item is not kept by
+// the Podcast.
+func (fw *FeedWriter) WriteItem(i Item) error {
+	if fw.closed {
+		return errors.New("podcast.FeedWriter.WriteItem: writer is closed")
+	}
+	if err := fw.p.prepareItem(&i); err != nil {
+		return err
+	}
+	if err := fw.start(); err != nil {
+		return err
+	}
+
+	// indented as a child of the channel, the same as Encode
+	if _, err := io.WriteString(fw.w, "\n"); err != nil {
+		return errors.Wrap(err, "podcast.FeedWriter.WriteItem: w.Write returned error")
+	}
+	e := xml.NewEncoder(fw.w)
+	e.Indent("    ", "  ")
+	if err := e.Encode(&i); err != nil {
+		return errors.Wrap(err, "podcast.FeedWriter.WriteItem: e.Encode returned error")
+	}
+	return nil
+}
+
+// Close writes the end of the channel and rss elements.  It does not
+// close the underlying io.Writer.
+func (fw *FeedWriter) Close() error {
+	if fw.closed {
+		return nil
+	}
+	if err := fw.start(); err != nil {
+		return err
+	}
+	fw.closed = true
+	if _, err := fw.w.Write(fw.footer); err != nil {
+		return errors.Wrap(err, "podcast.FeedWriter.Close: w.Write returned error")
+	}
+	return nil
+}
+
+// start writes the channel up to its closing tag, keeping the rest as
+// the footer written by Close.
+func (fw *FeedWriter) start() error {
+	if fw.started {
+		return nil
+	}
+	fw.started = true
+
+	var b bytes.Buffer
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	if err := fw.p.encode(&b, fw.p.wrap(true)); err != nil {
+		return errors.Wrap(err, "podcast.FeedWriter: encode returned error")
+	}
+	out := b.Bytes()
+	end := bytes.LastIndex(out, []byte("</channel>"))
+	if end < 0 {
+		return errors.New("podcast.FeedWriter: channel not found")
+	}
+	end = bytes.LastIndexByte(out[:end], '\n')
+	fw.footer = out[end:]
+	if _, err := fw.w.Write(out[:end]); err != nil {
+		return errors.Wrap(err, "podcast.FeedWriter: w.Write returned error")
+	}
+	return nil
+}
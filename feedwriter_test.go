@@ -0,0 +1,105 @@
+package podcast_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedWriterMatchesEncode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://example.com/feed.rss")
+	s := p
+	var items []podcast.Item
+	for n := 1; n <= 3; n++ {
+		i := podcast.Item{Title: fmt.Sprint("Episode ", n), Description: "desc", PubDate: &pubDate}
+		i.AddEnclosure(fmt.Sprint("http://example.com/", n, ".mp3"), podcast.MP3, 183)
+		assert.NoError(t, i.AddTranscript(fmt.Sprint("http://example.com/", n, ".vtt"), podcast.TranscriptVTT, "en", ""))
+		items = append(items, i)
+		_, err := p.AddItem(i)
+		assert.NoError(t, err)
+	}
+	var want, got bytes.Buffer
+	assert.NoError(t, p.Encode(&want))
+
+	// act
+	fw := podcast.NewFeedWriter(&got, &s)
+	for _, i := range items {
+		assert.NoError(t, fw.WriteItem(i))
+	}
+	err := fw.Close()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, want.String(), got.String())
+	assert.Len(t, s.Items, 0)
+}
+
+func TestFeedWriterNoItems(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	var b bytes.Buffer
+
+	// act
+	fw := podcast.NewFeedWriter(&b, &p)
+	err := fw.Close()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "</pubDate>\n  </channel>\n</rss>")
+	assert.NoError(t, fw.Close())
+}
+
+func TestFeedWriterInvalidItem(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	var b bytes.Buffer
+	fw := podcast.NewFeedWriter(&b, &p)
+
+	// act
+	err := fw.WriteItem(podcast.Item{Title: "title"})
+
+	// assert
+	assert.EqualError(t, err, "Title and Description are required")
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestFeedWriterClosed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	var b bytes.Buffer
+	fw := podcast.NewFeedWriter(&b, &p)
+	assert.NoError(t, fw.Close())
+
+	// act
+	err := fw.WriteItem(podcast.Item{Title: "title", Description: "desc", Link: "http://example.com/1"})
+
+	// assert
+	assert.EqualError(t, err, "podcast.FeedWriter.WriteItem: writer is closed")
+}
+
+func TestFeedWriterError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	fw := podcast.NewFeedWriter(&errWriter{}, &p)
+
+	// act
+	err := fw.WriteItem(podcast.Item{Title: "title", Description: "desc", Link: "http://example.com/1"})
+
+	// assert
+	assert.Error(t, err)
+}
@@ -8,6 +8,19 @@ import (
 	"time"
 )
 
+func FuzzDecode(data []byte) int {
+	p, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+	var buf bytes.Buffer
+	if err := p.Encode(&buf); err != nil {
+		return 0
+	}
+
+	return 1
+}
+
 func FuzzItemAddDuration(data []byte) int {
 	input, read := binary.Varint(data)
 	if input <= 0 && read == 0 {
@@ -270,6 +283,40 @@ func FuzzPodcastEncode(data []byte) int {
 	return 1
 }
 
+func FuzzProbeMP4(data []byte) int {
+	if _, err := ProbeMP4(bytes.NewReader(data)); err != nil {
+		return 0
+	}
+
+	return 1
+}
+
+func FuzzParseChapters(data []byte) int {
+	c, err := ParseChapters(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+	var buf bytes.Buffer
+	if err := c.Encode(&buf); err != nil {
+		return 0
+	}
+
+	return 1
+}
+
+func FuzzDecodeTranscript(data []byte) int {
+	t, err := DecodeTranscript(bytes.NewReader(data), TranscriptVTT)
+	if err != nil {
+		return 0
+	}
+	var buf bytes.Buffer
+	if err := t.Encode(&buf, TranscriptSRT); err != nil {
+		return 0
+	}
+
+	return 1
+}
+
 func newPodcast(data []byte) Podcast {
 	return New(
 		string(data),
@@ -0,0 +1,94 @@
+package podcast
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"strings"
+)
+
+// GUID represents the guid of an Item, which podcast apps use to track
+// the episodes they have downloaded.
+//
+// IsPermaLink is "false" when the Value is not the URL of the episode,
+// and is omitted otherwise as RSS 2.0 readers default it to "true".
+type GUID struct {
+	XMLName     xml.Name `xml:"guid"`
+	Value       string   `xml:",chardata"`
+	IsPermaLink string   `xml:"isPermaLink,attr,omitempty"`
+}
+
+// podcastGUIDNamespace is the UUIDv5 namespace of podcast:guid.
+//
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#guid
+var podcastGUIDNamespace = [16]byte{
+	0xea, 0xd4, 0xc2, 0x36, 0xbf, 0x58, 0x58, 0xc6,
+	0xa2, 0xc6, 0xa6, 0xb2, 0x8d, 0x12, 0x8c, 0xb6,
+}
+
+// PodcastGUID returns the podcast:guid of the feed at feedURL, the UUIDv5
+// of the URL without its scheme and trailing slashes, which stays the same
+// for the life of the feed even when it moves to a new URL.
+func PodcastGUID(feedURL string) string {
+	name := feedURL
+	if n := strings.Index(name, "://"); n >= 0 {
+		name = name[n+3:]
+	}
+	return uuidV5(podcastGUIDNamespace, strings.TrimRight(name, "/"))
+}
+
+// uuidV5 returns the name-based UUID of the name in the namespace as per
+// RFC 4122 section 4.3.
+func uuidV5(namespace [16]byte, name string) string {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	u := h.Sum(nil)[:16]
+	u[6] = u[6]&0x0F | 0x50 // version 5
+	u[8] = u[8]&0x3F | 0x80 // RFC 4122 variant
+	s := hex.EncodeToString(u)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// formatGUID returns the GUIDFormatted of the Item for its GUID, keeping
+// the IsPermaLink of an existing GUIDFormatted.
+func formatGUID(i *Item) *GUID {
+	if len(i.GUID) == 0 {
+		return nil
+	}
+	if i.GUIDFormatted != nil {
+		return &GUID{Value: i.GUID, IsPermaLink: i.GUIDFormatted.IsPermaLink}
+	}
+	g := &GUID{Value: i.GUID}
+	if !isPermaLink(i.GUID) {
+		g.IsPermaLink = "false"
+	}
+	return g
+}
+
+// isPermaLink reports whether the guid is an http or https URL.
+func isPermaLink(guid string) bool {
+	return strings.HasPrefix(guid, "http://") || strings.HasPrefix(guid, "https://")
+}
+
+// formattedItems returns the Items with their GUIDFormatted set from the
+// GUID, copying the Items that were not added with AddItem or had their
+// GUID changed since.
+func (p *Podcast) formattedItems() []*Item {
+	items := p.Items
+	copied := false
+	for n, i := range items {
+		if i.GUIDFormatted != nil && i.GUIDFormatted.Value == i.GUID ||
+			i.GUIDFormatted == nil && len(i.GUID) == 0 {
+			continue
+		}
+		if !copied {
+			items = append([]*Item(nil), items...)
+			copied = true
+		}
+		c := *i
+		c.GUIDFormatted = formatGUID(i)
+		items[n] = &c
+	}
+	return items
+}
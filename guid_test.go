@@ -0,0 +1,205 @@
+package podcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+var podcastGUIDTests = []struct {
+	url      string
+	expected string
+}{
+	{"https://mp3s.nashownotes.com/pc20rss.xml", "917393e3-1b1e-5cef-ace4-edaa54e1f810"},
+	{"http://mp3s.nashownotes.com/pc20rss.xml", "917393e3-1b1e-5cef-ace4-edaa54e1f810"},
+	{"https://mp3s.nashownotes.com/pc20rss.xml/", "917393e3-1b1e-5cef-ace4-edaa54e1f810"},
+	{"mp3s.nashownotes.com/pc20rss.xml", "917393e3-1b1e-5cef-ace4-edaa54e1f810"},
+}
+
+func TestPodcastGUID(t *testing.T) {
+	t.Parallel()
+	for _, pt := range podcastGUIDTests {
+		assert.EqualValues(t, pt.expected, podcast.PodcastGUID(pt.url), pt.url)
+	}
+}
+
+func TestAddItemGUIDIsPermaLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		guid     string
+		expected string
+	}{
+		{"http://example.com/1.mp3", ""},
+		{"https://example.com/1.mp3", ""},
+		{"episode-1", "false"},
+		{"8a4c7f51-5e87-4a3a-9d0f-4bb1d6bc1a0c", "false"},
+	}
+	for _, tt := range tests {
+		// arrange
+		p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+		i := podcast.Item{Title: "t", Description: "d", Link: "http://example.com/1", GUID: tt.guid}
+
+		// act
+		_, err := p.AddItem(i)
+
+		// assert
+		assert.NoError(t, err)
+		assert.EqualValues(t, tt.guid, p.Items[0].GUIDFormatted.Value)
+		assert.EqualValues(t, tt.expected, p.Items[0].GUIDFormatted.IsPermaLink, tt.guid)
+	}
+}
+
+func TestAddItemGUIDFormattedKept(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	i := podcast.Item{
+		Title:         "t",
+		Description:   "d",
+		Link:          "http://example.com/1",
+		GUIDFormatted: &podcast.GUID{Value: "http://example.com/1", IsPermaLink: "false"},
+	}
+
+	// act
+	_, err := p.AddItem(i)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "http://example.com/1", p.Items[0].GUID)
+	assert.EqualValues(t, "false", p.Items[0].GUIDFormatted.IsPermaLink)
+	assert.Contains(t, p.String(), `<guid isPermaLink="false">http://example.com/1</guid>`)
+}
+
+func TestAddItemGUIDNotReplacedByLink(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	i := podcast.Item{Title: "t", Description: "d", Link: "http://example.com/1", GUID: "episode-1"}
+
+	// act
+	_, err := p.AddItem(i)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "episode-1", p.Items[0].GUID)
+}
+
+func TestAddItemStrictGUIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate,
+		podcast.WithStrictGUIDs())
+	i := podcast.Item{Title: "t", Description: "d", Link: "http://example.com/1"}
+
+	// act
+	_, err := p.AddItem(i)
+	i.GUID = "episode-1"
+	_, err2 := p.AddItem(i)
+
+	// assert
+	assert.EqualError(t, err, "t: GUID is required instead of being derived from URLs")
+	assert.NoError(t, err2)
+	assert.Len(t, p.Items, 1)
+}
+
+func TestEncodeItemsAppendedDirectly(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.Items = append(p.Items, &podcast.Item{Title: "t", Description: "d", GUID: "episode-1"})
+
+	// act
+	out := p.String()
+
+	// assert
+	assert.Contains(t, out, `<guid isPermaLink="false">episode-1</guid>`)
+	assert.Nil(t, p.Items[0].GUIDFormatted, "the Item must not be modified")
+}
+
+func TestEncodeChannelPodcastGUID(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://mp3s.nashownotes.com/pc20rss.xml")
+
+	// act
+	out := p.String()
+
+	// assert
+	assert.Contains(t, out, `xmlns:podcast="https://podcastindex.org/namespace/1.0"`)
+	assert.Contains(t, out, "<podcast:guid>917393e3-1b1e-5cef-ace4-edaa54e1f810</podcast:guid>")
+	assert.Empty(t, p.PGUID)
+}
+
+func TestEncodeChannelPodcastGUIDKept(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://example.com/new.xml")
+	p.PGUID = "917393e3-1b1e-5cef-ace4-edaa54e1f810"
+
+	// act
+	out := p.String()
+
+	// assert
+	assert.Contains(t, out, "<podcast:guid>917393e3-1b1e-5cef-ace4-edaa54e1f810</podcast:guid>")
+	assert.Equal(t, 1, strings.Count(out, "<podcast:guid>"))
+}
+
+func TestDecodeGUID(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://mp3s.nashownotes.com/pc20rss.xml")
+	_, err := p.AddItem(podcast.Item{Title: "t", Description: "d", Link: "http://example.com/1", GUID: "episode-1"})
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	assert.NoError(t, p.Encode(&b))
+
+	// act
+	d, err := podcast.Decode(&b)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "917393e3-1b1e-5cef-ace4-edaa54e1f810", d.PGUID)
+	assert.Len(t, d.Items, 1)
+	assert.EqualValues(t, "episode-1", d.Items[0].GUID)
+	assert.EqualValues(t, "false", d.Items[0].GUIDFormatted.IsPermaLink)
+}
+
+func TestValidateGUIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.PGUID = "not-a-uuid"
+	p.Items = append(p.Items, &podcast.Item{
+		Title:         "t",
+		GUID:          "episode-1",
+		GUIDFormatted: &podcast.GUID{Value: "episode-1"},
+	})
+	s := podcast.New("title", "link", "description", &pubDate, &updatedDate,
+		podcast.WithStrictGUIDs())
+	s.Items = append(s.Items, &podcast.Item{Title: "t"})
+
+	// act
+	r := p.Validate(podcast.ProfileRSS, podcast.ProfilePodcasting20)
+	rs := s.Validate(podcast.ProfileRSS)
+
+	// assert
+	assert.True(t, hasFinding(r, "PGUID", "podcasting-channel-guid"))
+	assert.True(t, hasFinding(r, "Items[0].GUIDFormatted.IsPermaLink", "rss-item-guid-permalink"))
+	assert.True(t, hasFinding(rs, "Items[0].GUID", "rss-item-guid-required"))
+	assert.False(t, hasFinding(rs, "Items[0].GUID", "rss-item-guid"))
+}
@@ -0,0 +1,187 @@
+package podcast
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const handlerContentType = "application/rss+xml; charset=utf-8"
+
+// Handler is an http.Handler serving the Podcast returned by Feed, built
+// for podcast apps that poll feeds every few minutes:
+//   * The ETag is a hash of the encoded feed, so unchanged feeds are
+//     answered with 304 Not Modified to If-None-Match.
+//   * Last-Modified is taken from LastBuildDate, or PubDate, and honors
+//     If-Modified-Since when no If-None-Match is sent.
+//   * The response is gzipped when the request accepts it.
+//   * HEAD requests are answered with the headers only.
+type Handler struct {
+	// Feed returns the Podcast for the request.  Errors are logged to
+	// ErrorLog and answered with 500 Internal Server Error.
+	Feed func(r *http.Request) (*Podcast, error)
+
+	// Encode writes the Podcast, defaulting to Podcast.Encode.  Set it to
+	// (*Podcast).EncodeAtom or (*Podcast).EncodeJSONFeed along with the
+	// ContentType to serve the other formats.
+	Encode func(p *Podcast, w io.Writer) error
+
+	// ContentType defaults to "application/rss+xml; charset=utf-8".
+	ContentType string
+
+	// MaxAge sets the Cache-Control max-age when greater than zero.
+	MaxAge time.Duration
+
+	// ErrorLog logs the errors of Feed and Encode, which are not sent to
+	// the client.  Defaults to the log package's standard logger when nil.
+	ErrorLog *log.Logger
+}
+
+// NewHandler returns a Handler serving the RSS feed of the Podcast
+// returned by feed on each request.
+func NewHandler(feed func(r *http.Request) (*Podcast, error)) *Handler {
+	return &Handler{Feed: feed}
+}
+
+// ServeHTTP implements http.Handler for GET and HEAD requests.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+	p, err := h.Feed(r)
+	if err != nil {
+		h.serverError(w, errors.Wrap(err, "podcast.Handler: Feed returned error"))
+		return
+	}
+	encode, contentType := h.Encode, h.ContentType
+	if encode == nil {
+		encode = (*Podcast).Encode
+	}
+	if len(contentType) == 0 {
+		contentType = handlerContentType
+	}
+	var body bytes.Buffer
+	if err := encode(p, &body); err != nil {
+		h.serverError(w, errors.Wrap(err, "podcast.Handler: Encode returned error"))
+		return
+	}
+
+	// the gzipped body is a different representation with its own ETag
+	sum := sha256.Sum256(body.Bytes())
+	tag := hex.EncodeToString(sum[:16])
+	gzipped := acceptsGzip(r)
+	etag := `"` + tag + `"`
+	if gzipped {
+		etag = `"` + tag + `-gzip"`
+	}
+
+	hdr := w.Header()
+	hdr.Set("ETag", etag)
+	hdr.Add("Vary", "Accept-Encoding")
+	if h.MaxAge > 0 {
+		hdr.Set("Cache-Control",
+			"max-age="+strconv.FormatInt(int64(h.MaxAge/time.Second), 10))
+	}
+	modified := p.lastModified()
+	if !modified.IsZero() {
+		hdr.Set("Last-Modified", modified.Format(http.TimeFormat))
+	}
+	if notModified(r, tag, modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	out := body.Bytes()
+	if gzipped {
+		var gz bytes.Buffer
+		zw := gzip.NewWriter(&gz)
+		if _, err := zw.Write(out); err != nil || zw.Close() != nil {
+			h.serverError(w, errors.New("podcast.Handler: gzip failed"))
+			return
+		}
+		out = gz.Bytes()
+		hdr.Set("Content-Encoding", "gzip")
+	}
+	hdr.Set("Content-Type", contentType)
+	hdr.Set("Content-Length", strconv.Itoa(len(out)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(out)
+	}
+}
+
+// serverError logs the err and answers with 500 Internal Server Error,
+// without the details of err that are meant for the publisher.
+func (h *Handler) serverError(w http.ResponseWriter, err error) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Println(err)
+	} else {
+		log.Println(err)
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError),
+		http.StatusInternalServerError)
+}
+
+// lastModified returns the LastBuildDate, or the PubDate, of the Podcast
+// truncated to the second resolution of HTTP dates.
+func (p *Podcast) lastModified() time.Time {
+	for _, d := range []string{p.LastBuildDate, p.PubDate} {
+		if t := parseDate(d); t != nil {
+			return t.UTC().Truncate(time.Second)
+		}
+	}
+	return time.Time{}
+}
+
+// notModified reports whether the conditional request matches the
+// feed's tag, ignoring any "W/" prefix and "-gzip" suffix as If-None-Match
+// uses the weak comparison, or else has not been modified since.
+func notModified(r *http.Request, tag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); len(inm) > 0 {
+		for _, t := range strings.Split(inm, ",") {
+			t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+			if t == "*" || strings.TrimSuffix(strings.Trim(t, `"`), "-gzip") == tag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); len(ims) > 0 && !modified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !modified.After(t)
+	}
+	return false
+}
+
+// acceptsGzip reports whether the Accept-Encoding of the request lists
+// gzip without a zero quality.
+func acceptsGzip(r *http.Request) bool {
+	for _, e := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(e, ";")
+		if !strings.EqualFold(strings.TrimSpace(parts[0]), "gzip") {
+			continue
+		}
+		for _, param := range parts[1:] {
+			param = strings.Replace(param, " ", "", -1)
+			if q := strings.TrimPrefix(param, "q="); q != param {
+				if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package podcast_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func handlerFeed(r *http.Request) (*podcast.Podcast, error) {
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	return &p, nil
+}
+
+func serve(h http.Handler, method string, headers ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/feed.rss", nil)
+	for n := 0; n+1 < len(headers); n += 2 {
+		r.Header.Set(headers[n], headers[n+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandlerGet(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	h.MaxAge = 5 * time.Minute
+	p, _ := handlerFeed(nil)
+
+	// act
+	w := serve(h, http.MethodGet)
+
+	// assert
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.EqualValues(t, "application/rss+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.EqualValues(t, "Mon, 06 Feb 2017 08:21:52 GMT", w.Header().Get("Last-Modified"))
+	assert.EqualValues(t, "max-age=300", w.Header().Get("Cache-Control"))
+	assert.EqualValues(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, w.Header().Get("ETag"))
+	assert.EqualValues(t, p.String(), w.Body.String())
+}
+
+func TestHandlerNotModified(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	etag := serve(h, http.MethodGet).Header().Get("ETag")
+	tests := [][]string{
+		{"If-None-Match", etag},
+		{"If-None-Match", `"other", W/` + etag},
+		{"If-None-Match", etag[:len(etag)-1] + `-gzip"`},
+		{"If-None-Match", "*"},
+		{"If-Modified-Since", "Mon, 06 Feb 2017 08:21:52 GMT"},
+		{"If-Modified-Since", "Tue, 07 Feb 2017 00:00:00 GMT"},
+	}
+	for _, tt := range tests {
+		// act
+		w := serve(h, http.MethodGet, tt...)
+
+		// assert
+		assert.EqualValues(t, http.StatusNotModified, w.Code, tt[1])
+		assert.EqualValues(t, 0, w.Body.Len(), tt[1])
+		assert.EqualValues(t, etag, w.Header().Get("ETag"), tt[1])
+	}
+}
+
+func TestHandlerModified(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	tests := [][]string{
+		{"If-None-Match", `"other"`},
+		{"If-Modified-Since", "Sun, 05 Feb 2017 00:00:00 GMT"},
+		{"If-Modified-Since", "not a date"},
+		// If-None-Match takes precedence over If-Modified-Since
+		{"If-None-Match", `"other"`, "If-Modified-Since", "Tue, 07 Feb 2017 00:00:00 GMT"},
+	}
+	for _, tt := range tests {
+		// act
+		w := serve(h, http.MethodGet, tt...)
+
+		// assert
+		assert.EqualValues(t, http.StatusOK, w.Code, tt[1])
+	}
+}
+
+func TestHandlerGzip(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	p, _ := handlerFeed(nil)
+	plain := serve(h, http.MethodGet).Header().Get("ETag")
+
+	// act
+	w := serve(h, http.MethodGet, "Accept-Encoding", "br, gzip;q=0.8")
+	refused := serve(h, http.MethodGet, "Accept-Encoding", "gzip;q=0")
+
+	// assert
+	assert.EqualValues(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.NotEqual(t, plain, w.Header().Get("ETag"))
+	zr, err := gzip.NewReader(w.Body)
+	if assert.NoError(t, err) {
+		b, _ := ioutil.ReadAll(zr)
+		assert.EqualValues(t, p.String(), string(b))
+	}
+	assert.EqualValues(t, "", refused.Header().Get("Content-Encoding"))
+}
+
+func TestHandlerHead(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	get := serve(h, http.MethodGet)
+
+	// act
+	w := serve(h, http.MethodHead)
+
+	// assert
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.EqualValues(t, 0, w.Body.Len())
+	assert.EqualValues(t, get.Header().Get("Content-Length"), w.Header().Get("Content-Length"))
+	assert.EqualValues(t, get.Header().Get("ETag"), w.Header().Get("ETag"))
+}
+
+func TestHandlerErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	failing := podcast.NewHandler(func(r *http.Request) (*podcast.Podcast, error) {
+		return nil, errors.New("feed not found")
+	})
+	var logged bytes.Buffer
+	failing.ErrorLog = log.New(&logged, "", 0)
+
+	// act
+	post := serve(h, http.MethodPost)
+	failed := serve(failing, http.MethodGet)
+
+	// assert
+	assert.EqualValues(t, http.StatusMethodNotAllowed, post.Code)
+	assert.EqualValues(t, "GET, HEAD", post.Header().Get("Allow"))
+	assert.EqualValues(t, http.StatusInternalServerError, failed.Code)
+	assert.EqualValues(t, "Internal Server Error\n", failed.Body.String())
+	assert.EqualValues(t, "podcast.Handler: Feed returned error: feed not found\n", logged.String())
+}
+
+func TestHandlerJSONFeed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewHandler(handlerFeed)
+	h.Encode = (*podcast.Podcast).EncodeJSONFeed
+	h.ContentType = "application/feed+json"
+	p, _ := handlerFeed(nil)
+	var b bytes.Buffer
+	_ = p.EncodeJSONFeed(&b)
+
+	// act
+	w := serve(h, http.MethodGet)
+
+	// assert
+	assert.EqualValues(t, "application/feed+json", w.Header().Get("Content-Type"))
+	assert.EqualValues(t, b.String(), w.Body.String())
+}
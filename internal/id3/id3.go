@@ -0,0 +1,199 @@
+// Package id3 reads the ID3v2 tags of MP3 files for the podcast package
+// and command.
+package id3
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Specifications: https://id3.org/id3v2.4.0-structure
+//
+
+// maxFrameSize limits the text frames that are read, as the sizes come
+// straight from the file.  Larger frames, such as cover art, are skipped
+// without being read into memory.
+const maxFrameSize = 1 << 16
+
+// Tags are the text frames of the ID3v2 tag of an MP3 file used for the
+// episodes.
+type Tags struct {
+	// Title is the TIT2 frame.
+	Title string
+	// Comment is the text of the first COMM frame.
+	Comment string
+}
+
+// Read reads the title and comment of the ID3v2.2, 2.3 or 2.4 tag at
+// the start of r, which are empty when there is no tag.
+//
+// The frames are walked one at a time, so that tags with large pictures
+// or invalid sizes are never read into memory as a whole.
+func Read(r io.Reader) (*Tags, error) {
+	tags := &Tags{}
+	br := bufio.NewReader(r)
+	h, err := br.Peek(10)
+	if err != nil {
+		return tags, nil
+	}
+	size, ok := Size(h)
+	if !ok {
+		return tags, nil
+	}
+	version, flags := h[3], h[5]
+	if _, err := br.Discard(10); err != nil {
+		return tags, nil
+	}
+	remaining := size - 10
+
+	if flags&0x40 != 0 {
+		// skip the extended header
+		ext, err := br.Peek(4)
+		if err != nil {
+			return tags, nil
+		}
+		n := int(syncsafe(ext))
+		if version == 3 {
+			n = int(bigEndian(ext)) + 4
+		}
+		if n > remaining {
+			return tags, nil
+		}
+		if _, err := br.Discard(n); err != nil {
+			return tags, nil
+		}
+		remaining -= n
+	}
+
+	idLen, headerLen := 4, 10
+	if version == 2 {
+		idLen, headerLen = 3, 6
+	}
+	for remaining >= headerLen {
+		fh, err := br.Peek(headerLen)
+		if err != nil || fh[0] == 0 {
+			break
+		}
+		id := string(fh[:idLen])
+		var n int
+		switch version {
+		case 2:
+			n = int(fh[3])<<16 | int(fh[4])<<8 | int(fh[5])
+		case 3:
+			n = int(bigEndian(fh[4:8]))
+		default:
+			n = int(syncsafe(fh[4:8]))
+		}
+		if n < 0 || n > remaining-headerLen {
+			break
+		}
+		if _, err := br.Discard(headerLen); err != nil {
+			break
+		}
+		remaining -= headerLen + n
+
+		wanted := id == "TIT2" || id == "TT2" ||
+			(id == "COMM" || id == "COM") && len(tags.Comment) == 0
+		if !wanted || n > maxFrameSize {
+			if _, err := br.Discard(n); err != nil {
+				break
+			}
+			continue
+		}
+		body := make([]byte, n)
+		if _, err := io.ReadFull(br, body); err != nil {
+			break
+		}
+		switch id {
+		case "TIT2", "TT2":
+			tags.Title = decodeText(body)
+		case "COMM", "COM":
+			if len(body) > 4 {
+				// the language and the short description precede the text
+				enc, text := body[0], body[4:]
+				if k := terminator(enc, text); k >= 0 {
+					tags.Comment = decodeText(append([]byte{enc}, text[k:]...))
+				}
+			}
+		}
+	}
+	return tags, nil
+}
+
+// Size returns the size of the ID3v2 tag, including its header and
+// footer, of the 10 byte header h, reporting whether h is an ID3v2 header.
+func Size(h []byte) (int, bool) {
+	if len(h) < 10 || !bytes.HasPrefix(h, []byte("ID3")) {
+		return 0, false
+	}
+	size := int(syncsafe(h[6:10])) + 10
+	if h[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+	return size, true
+}
+
+// decodeText decodes the text of a frame, the encoding byte followed by the
+// ISO-8859-1, UTF-16 or UTF-8 text.
+func decodeText(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	enc, text := body[0], body[1:]
+	var s string
+	switch enc {
+	case 1, 2:
+		be := enc == 2
+		if len(text) >= 2 && text[0] == 0xFE && text[1] == 0xFF {
+			be, text = true, text[2:]
+		} else if len(text) >= 2 && text[0] == 0xFF && text[1] == 0xFE {
+			be, text = false, text[2:]
+		}
+		u := make([]uint16, len(text)/2)
+		for n := range u {
+			if be {
+				u[n] = uint16(text[2*n])<<8 | uint16(text[2*n+1])
+			} else {
+				u[n] = uint16(text[2*n+1])<<8 | uint16(text[2*n])
+			}
+		}
+		s = string(utf16.Decode(u))
+	case 3:
+		s = string(text)
+	default:
+		r := make([]rune, len(text))
+		for n, b := range text {
+			r[n] = rune(b)
+		}
+		s = string(r)
+	}
+	return strings.TrimSpace(strings.TrimRight(s, "\x00"))
+}
+
+// terminator returns the index after the null terminator of the first
+// string of text in the encoding, or -1 when there is none.
+func terminator(enc byte, text []byte) int {
+	if enc == 1 || enc == 2 {
+		for n := 0; n+1 < len(text); n += 2 {
+			if text[n] == 0 && text[n+1] == 0 {
+				return n + 2
+			}
+		}
+		return -1
+	}
+	if n := bytes.IndexByte(text, 0); n >= 0 {
+		return n + 1
+	}
+	return -1
+}
+
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 | uint32(b[1]&0x7F)<<14 | uint32(b[2]&0x7F)<<7 | uint32(b[3]&0x7F)
+}
+
+func bigEndian(b []byte) uint32 {
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
@@ -0,0 +1,75 @@
+package id3_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/eduncan911/podcast/internal/id3"
+	"github.com/stretchr/testify/assert"
+)
+
+// id3v23 returns an ID3v2.3 tag of the frames, each an id and its body.
+func id3v23(frames ...string) []byte {
+	var tag []byte
+	for n := 0; n+1 < len(frames); n += 2 {
+		f := append([]byte(frames[n]), 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(f[4:8], uint32(len(frames[n+1])))
+		tag = append(append(tag, f...), frames[n+1]...)
+	}
+	size := len(tag)
+	h := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(h, tag...)
+}
+
+func utf16Text(s string) string {
+	b := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = append(b, byte(u), byte(u>>8))
+	}
+	return string(b)
+}
+
+func TestReadID3(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	comment := "\x01eng" + utf16Text("short") + "\x00\x00" + utf16Text("Show notes ü")
+	data := id3v23(
+		"APIC", strings.Repeat("p", 1<<17),
+		"TIT2", "\x00Caf\xe9",
+		"COMM", comment,
+		"COMM", "\x03engsecond\x00ignored",
+	)
+
+	// act
+	tags, err := id3.Read(bytes.NewReader(data))
+	none, err2 := id3.Read(strings.NewReader("no tag"))
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, err2)
+	assert.Equal(t, "Café", tags.Title)
+	assert.Equal(t, "Show notes ü", tags.Comment)
+	assert.Equal(t, &id3.Tags{}, none)
+}
+
+func TestReadID3Truncated(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	data := id3v23("TIT2", "\x03Title")
+	data[6], data[7], data[8], data[9] = 0x7F, 0x7F, 0x7F, 0x7F // 256 MB
+	frame := bytes.Index(data, []byte("TIT2"))
+	binary.BigEndian.PutUint32(data[frame+4:], 1<<27)
+
+	// act
+	tags, err := id3.Read(bytes.NewReader(data))
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, &id3.Tags{}, tags)
+}
@@ -3,8 +3,15 @@ package podcast
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/pkg/errors"
 )
 
 // Item represents a single entry in a podcast.
@@ -26,7 +33,8 @@ import (
 // - Use Enclosure.Type instead of setting TypeFormatted for valid extensions.
 type Item struct {
 	XMLName          xml.Name   `xml:"item"`
-	GUID             string     `xml:"guid"`
+	GUID             string     `xml:"-"`
+	GUIDFormatted    *GUID      `xml:"guid"`
 	Title            string     `xml:"title"`
 	Link             string     `xml:"link"`
 	Description      string     `xml:"description"`
@@ -48,6 +56,15 @@ type Item struct {
 	IExplicit          string `xml:"itunes:explicit,omitempty"`
 	IIsClosedCaptioned string `xml:"itunes:isClosedCaptioned,omitempty"`
 	IOrder             string `xml:"itunes:order,omitempty"`
+	ITitle             string `xml:"itunes:title,omitempty"`
+	IEpisodeType       string `xml:"itunes:episodeType,omitempty"`
+	ISeason            int    `xml:"itunes:season,omitempty"`
+	IEpisode           int    `xml:"itunes:episode,omitempty"`
+
+	// https://github.com/Podcastindex-org/podcast-namespace
+	PTranscripts []*PTranscript `xml:"podcast:transcript"`
+	PChapters    *PChapters
+	PValue       *PValue
 }
 
 // AddEnclosure adds the downloadable asset to the podcast Item.
@@ -58,6 +75,76 @@ func (i *Item) AddEnclosure(
 		Type:   enclosureType,
 		Length: lengthInBytes,
 	}
+	i.setClosedCaptioned()
+}
+
+// AddEnclosureFromFile adds the Enclosure of the local file at path, which
+// is downloaded from url, along with its iTunes duration.
+//
+// The Length is the size of the file and the EnclosureType and duration
+// are read from the file itself:
+//   * MP3 files by their MPEG frames, using the Xing or VBRI header of
+//     VBR files when present.
+//   * MP4, M4A, M4B, M4V and MOV files by their moov box, see ProbeMP4.
+//   * Other files by EnclosureTypeByExtension, or else by
+//     EnclosureTypeByContent, without a duration.
+func (i *Item) AddEnclosureFromFile(url, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "podcast.AddEnclosureFromFile: os.Open returned error")
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "podcast.AddEnclosureFromFile: f.Stat returned error")
+	}
+
+	et, ok := EnclosureTypeByExtension(filepath.Ext(path))
+	if !ok {
+		head := make([]byte, 512)
+		n, _ := io.ReadFull(f, head)
+		if et, ok = EnclosureTypeByContent(head[:n]); !ok {
+			return errors.New("podcast.AddEnclosureFromFile: " + path +
+				" is not a supported file type")
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return errors.Wrap(err, "podcast.AddEnclosureFromFile: f.Seek returned error")
+		}
+	}
+
+	var d time.Duration
+	switch et {
+	case MP3:
+		d, err = mp3Duration(f)
+	case MP4, M4A, M4B, M4V, MOV:
+		var info *MP4Info
+		if info, err = ProbeMP4(f); err == nil {
+			d = info.Duration
+			if et != M4B || info.Type != M4A {
+				et = info.Type
+			}
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "podcast.AddEnclosureFromFile: "+path)
+	}
+	i.AddEnclosure(url, et, fi.Size())
+	i.AddDuration(int64((d + time.Second/2) / time.Second))
+	return nil
+}
+
+// AddEpisodeType adds the iTunes episode type: full, trailer or bonus.
+func (i *Item) AddEpisodeType(episodeType EpisodeType) {
+	i.IEpisodeType = episodeType.String()
+}
+
+// AddSeasonEpisode adds the iTunes season and episode numbers.
+//
+// A season of 0 omits the season; the episode number is required for
+// full episodes of serial shows.  Negative numbers are rejected by AddItem.
+func (i *Item) AddSeasonEpisode(season, episode int) {
+	i.ISeason = season
+	i.IEpisode = episode
 }
 
 // AddImage adds the image as an iTunes-only IImage.  RSS 2.0 does not have
@@ -77,7 +164,8 @@ func (i *Item) AddImage(url string) {
 
 // AddPubDate adds the datetime as a parsed PubDate.
 //
-// UTC time is used by default.
+// A nil or zero datetime leaves the PubDateFormatted empty, which AddItem
+// sets with the Podcast's clock unless StrictDates is enabled.
 func (i *Item) AddPubDate(datetime *time.Time) {
 	i.PubDate = datetime
 	i.PubDateFormatted = parseDateRFC1123Z(i.PubDate)
@@ -136,9 +224,23 @@ var parseDuration = func(duration int64) string {
 	return fmt.Sprintf("%d:%02d", m, s)
 }
 
+// parseDurationSeconds reverses parseDuration, also accepting a plain
+// number of seconds.  Invalid durations return 0.
+var parseDurationSeconds = func(duration string) int64 {
+	var seconds int64
+	for _, part := range strings.Split(duration, ":") {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
+
 var parseDateRFC1123Z = func(t *time.Time) string {
 	if t != nil && !t.IsZero() {
 		return t.Format(time.RFC1123Z)
 	}
-	return time.Now().UTC().Format(time.RFC1123Z)
+	return ""
 }
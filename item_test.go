@@ -2,6 +2,7 @@ package podcast_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/eduncan911/podcast"
 	"github.com/stretchr/testify/assert"
@@ -83,3 +84,26 @@ func TestItemAddDurationLessThanZero(t *testing.T) {
 	// assert
 	assert.EqualValues(t, "", i.IDuration)
 }
+
+func TestItemAddPubDateNilUsesClock(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate,
+		podcast.WithClock(func() time.Time { return createdDate }))
+	i := podcast.Item{
+		Title:       "item.title",
+		Description: "item.desc",
+		Link:        "http://example.com/article.html",
+	}
+
+	// act
+	i.AddPubDate(nil)
+	empty := i.PubDateFormatted
+	_, err := p.AddItem(i)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+	assert.EqualValues(t, createdDate.UTC().Format(time.RFC1123Z), p.Items[0].PubDateFormatted)
+}
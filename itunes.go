@@ -1,15 +1,19 @@
 package podcast
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+
+	"github.com/pkg/errors"
+)
 
 // Specifications: https://help.apple.com/itc/podcasts_connect/#/itcb54353390
 //
 
 // ICategory is a 2-tier classification system for iTunes.
 type ICategory struct {
-	XMLName     xml.Name `xml:"itunes:category"`
-	Text        string   `xml:"text,attr"`
-	ICategories []*ICategory
+	XMLName     xml.Name     `xml:"itunes:category"`
+	Text        string       `xml:"text,attr"`
+	ICategories []*ICategory `xml:"itunes:category"`
 }
 
 // IImage represents an iTunes image.
@@ -32,3 +36,67 @@ type ISummary struct {
 	XMLName xml.Name `xml:"itunes:summary"`
 	Text    string   `xml:",cdata"`
 }
+
+// EpisodeType specifies the itunes:episodeType of an Item.
+const (
+	EpisodeFull EpisodeType = iota
+	EpisodeTrailer
+	EpisodeBonus
+)
+
+// EpisodeType specifies the itunes:episodeType of an Item.
+type EpisodeType int
+
+// String returns the iTunes encoding of the specified EpisodeType.
+func (et EpisodeType) String() string {
+	switch et {
+	case EpisodeFull:
+		return "full"
+	case EpisodeTrailer:
+		return "trailer"
+	case EpisodeBonus:
+		return "bonus"
+	}
+	return ""
+}
+
+// ShowType specifies the itunes:type of a Podcast.
+const (
+	ShowEpisodic ShowType = iota
+	ShowSerial
+)
+
+// ShowType specifies the itunes:type of a Podcast.
+//
+// Episodic shows are presented newest episode first, while Serial shows
+// are presented oldest first and require episode numbers.
+type ShowType int
+
+// String returns the iTunes encoding of the specified ShowType.
+func (st ShowType) String() string {
+	switch st {
+	case ShowEpisodic:
+		return "episodic"
+	case ShowSerial:
+		return "serial"
+	}
+	return ""
+}
+
+// validateItemITunes checks the iTunes season, episode and episode type
+// of the Item against the Podcast's show type.
+func (p *Podcast) validateItemITunes(i *Item) error {
+	if i.ISeason < 0 || i.IEpisode < 0 {
+		return errors.New(i.Title + ": ISeason and IEpisode must be positive numbers")
+	}
+	switch i.IEpisodeType {
+	case "", EpisodeFull.String():
+		if p.IType == ShowSerial.String() && i.IEpisode == 0 {
+			return errors.New(i.Title + ": IEpisode is required for serial shows")
+		}
+	case EpisodeTrailer.String(), EpisodeBonus.String():
+	default:
+		return errors.New(i.Title + ": IEpisodeType must be full, trailer or bonus")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package podcast_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+var episodeTypeTests = []struct {
+	t        podcast.EpisodeType
+	expected string
+}{
+	{podcast.EpisodeFull, "full"},
+	{podcast.EpisodeTrailer, "trailer"},
+	{podcast.EpisodeBonus, "bonus"},
+	{99, ""},
+}
+
+func TestEpisodeTypes(t *testing.T) {
+	t.Parallel()
+	for _, et := range episodeTypeTests {
+		et := et
+		t.Run(fmt.Sprint(int(et.t)), func(t *testing.T) {
+			t.Parallel()
+
+			assert.EqualValues(t, et.expected, et.t.String())
+		})
+	}
+}
+
+var showTypeTests = []struct {
+	t        podcast.ShowType
+	expected string
+}{
+	{podcast.ShowEpisodic, "episodic"},
+	{podcast.ShowSerial, "serial"},
+	{99, ""},
+}
+
+func TestShowTypes(t *testing.T) {
+	t.Parallel()
+	for _, st := range showTypeTests {
+		st := st
+		t.Run(fmt.Sprint(int(st.t)), func(t *testing.T) {
+			t.Parallel()
+
+			assert.EqualValues(t, st.expected, st.t.String())
+		})
+	}
+}
+
+func TestAddItemNegativeSeasonEpisode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.AddSeasonEpisode(-1, 2)
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ISeason and IEpisode must be positive numbers")
+}
+
+func TestAddItemInvalidEpisodeType(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.IEpisodeType = "teaser"
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "IEpisodeType must be full, trailer or bonus")
+}
+
+func TestAddItemSerialMissingEpisode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.AddShowType(podcast.ShowSerial)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "IEpisode is required for serial shows")
+}
+
+func TestAddItemSerialTrailerWithoutEpisode(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.AddShowType(podcast.ShowSerial)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.AddEpisodeType(podcast.EpisodeTrailer)
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 1, added)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,256 @@
+package podcast
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://www.jsonfeed.org/version/1.1/
+//
+
+const (
+	jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+	jsonITunesAbout = "https://help.apple.com/itc/podcasts_connect/#/itcb54353390"
+)
+
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url,omitempty"`
+	NextURL     string          `json:"next_url,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Icon        string          `json:"icon,omitempty"`
+	Authors     []*jsonAuthor   `json:"authors,omitempty"`
+	Language    string          `json:"language,omitempty"`
+	Expired     bool            `json:"expired,omitempty"`
+	Hubs        []*jsonHub      `json:"hubs,omitempty"`
+	ITunes      *jsonITunesFeed `json:"_itunes,omitempty"`
+	Items       []*jsonItem     `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string            `json:"id"`
+	URL           string            `json:"url,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	ContentHTML   string            `json:"content_html"`
+	Summary       string            `json:"summary,omitempty"`
+	Image         string            `json:"image,omitempty"`
+	DatePublished string            `json:"date_published,omitempty"`
+	Authors       []*jsonAuthor     `json:"authors,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Attachments   []*jsonAttachment `json:"attachments,omitempty"`
+	ITunes        *jsonITunesItem   `json:"_itunes,omitempty"`
+}
+
+type jsonHub struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonAttachment struct {
+	URL               string `json:"url"`
+	MimeType          string `json:"mime_type"`
+	SizeInBytes       int64  `json:"size_in_bytes,omitempty"`
+	DurationInSeconds int64  `json:"duration_in_seconds,omitempty"`
+}
+
+type jsonITunesFeed struct {
+	About      string                `json:"about"`
+	Author     string                `json:"author,omitempty"`
+	Subtitle   string                `json:"subtitle,omitempty"`
+	Summary    string                `json:"summary,omitempty"`
+	Block      string                `json:"block,omitempty"`
+	Explicit   string                `json:"explicit,omitempty"`
+	Complete   string                `json:"complete,omitempty"`
+	NewFeedURL string                `json:"new_feed_url,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Owner      *jsonITunesOwner      `json:"owner,omitempty"`
+	Categories []*jsonITunesCategory `json:"categories,omitempty"`
+}
+
+type jsonITunesOwner struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type jsonITunesCategory struct {
+	Text          string                `json:"text"`
+	Subcategories []*jsonITunesCategory `json:"subcategories,omitempty"`
+}
+
+type jsonITunesItem struct {
+	About             string `json:"about"`
+	Author            string `json:"author,omitempty"`
+	Title             string `json:"title,omitempty"`
+	Subtitle          string `json:"subtitle,omitempty"`
+	Summary           string `json:"summary,omitempty"`
+	Duration          string `json:"duration,omitempty"`
+	Explicit          string `json:"explicit,omitempty"`
+	IsClosedCaptioned string `json:"is_closed_captioned,omitempty"`
+	Order             string `json:"order,omitempty"`
+	EpisodeType       string `json:"episode_type,omitempty"`
+	Season            int    `json:"season,omitempty"`
+	Episode           int    `json:"episode,omitempty"`
+}
+
+// EncodeJSONFeed writes the bytes to the io.Writer stream in JSON Feed 1.1
+// specification.
+//
+// Each Item becomes an item whose id is the GUID, or else the Enclosure URL
+// or Link as the id is required, and whose attachments are derived from the
+// Enclosure: the URL, the MIME type of Enclosure.Type, the Length in bytes
+// and the IDuration in seconds.  The iTunes fields are written to "_itunes"
+// extension objects on both the feed and its items.
+func (p *Podcast) EncodeJSONFeed(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	if err := e.Encode(p.jsonFeed()); err != nil {
+		return errors.Wrap(err, "podcast.EncodeJSONFeed: e.Encode returned error")
+	}
+	return nil
+}
+
+func (p *Podcast) jsonFeed() *jsonFeed {
+	f := &jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       p.Title,
+		HomePageURL: p.Link,
+		Description: p.Description,
+		Language:    p.Language,
+		Expired:     p.IComplete == "yes",
+		ITunes:      p.jsonITunes(),
+		Items:       []*jsonItem{},
+	}
+	if p.AtomLink != nil {
+		f.FeedURL = p.AtomLink.HREF
+	}
+	for _, l := range p.AtomLinks {
+		switch l.Rel {
+		case "next":
+			f.NextURL = l.HREF
+		case "hub":
+			f.Hubs = append(f.Hubs, &jsonHub{Type: "WebSub", URL: l.HREF})
+		}
+	}
+	if p.IImage != nil {
+		f.Icon = p.IImage.HREF
+	}
+	if a := p.atomAuthor(); a != nil {
+		f.Authors = []*jsonAuthor{{Name: a.Name}}
+	}
+	for _, i := range p.Items {
+		f.Items = append(f.Items, jsonFeedItem(i))
+	}
+	return f
+}
+
+func (p *Podcast) jsonITunes() *jsonITunesFeed {
+	it := &jsonITunesFeed{
+		About:      jsonITunesAbout,
+		Author:     p.IAuthor,
+		Subtitle:   p.ISubtitle,
+		Block:      p.IBlock,
+		Explicit:   p.IExplicit,
+		Complete:   p.IComplete,
+		NewFeedURL: p.INewFeedURL,
+		Type:       p.IType,
+		Categories: jsonITunesCategories(p.ICategories),
+	}
+	if p.ISummary != nil {
+		it.Summary = p.ISummary.Text
+	}
+	if p.IOwner != nil {
+		it.Owner = &jsonITunesOwner{Name: p.IOwner.Name, Email: p.IOwner.Email}
+	}
+	return it
+}
+
+func jsonITunesCategories(cats []*ICategory) []*jsonITunesCategory {
+	var list []*jsonITunesCategory
+	for _, c := range cats {
+		list = append(list, &jsonITunesCategory{
+			Text:          c.Text,
+			Subcategories: jsonITunesCategories(c.ICategories),
+		})
+	}
+	return list
+}
+
+func jsonFeedItem(i *Item) *jsonItem {
+	ji := &jsonItem{
+		ID:          itemID(i),
+		URL:         i.Link,
+		Title:       i.Title,
+		ContentHTML: i.Description,
+		Summary:     i.ISubtitle,
+		ITunes:      jsonITunesFeedItem(i),
+	}
+	if i.IImage != nil {
+		ji.Image = i.IImage.HREF
+	}
+	if t := i.PubDate; t != nil && !t.IsZero() {
+		ji.DatePublished = t.Format(time.RFC3339)
+	} else if t := parseDate(i.PubDateFormatted); t != nil {
+		ji.DatePublished = t.Format(time.RFC3339)
+	}
+	if i.Author != nil {
+		a := atomPersonFormatted(parseAuthorNameEmail(i.Author))
+		ji.Authors = []*jsonAuthor{{Name: a.Name}}
+	}
+	if len(i.Category) > 0 {
+		ji.Tags = []string{i.Category}
+	}
+	if i.Enclosure != nil {
+		ji.Attachments = []*jsonAttachment{{
+			URL:               i.Enclosure.URL,
+			MimeType:          i.Enclosure.Type.String(),
+			SizeInBytes:       i.Enclosure.Length,
+			DurationInSeconds: parseDurationSeconds(i.IDuration),
+		}}
+	}
+	return ji
+}
+
+func jsonITunesFeedItem(i *Item) *jsonITunesItem {
+	it := &jsonITunesItem{
+		About:             jsonITunesAbout,
+		Author:            i.IAuthor,
+		Title:             i.ITitle,
+		Subtitle:          i.ISubtitle,
+		Duration:          i.IDuration,
+		Explicit:          i.IExplicit,
+		IsClosedCaptioned: i.IIsClosedCaptioned,
+		Order:             i.IOrder,
+		EpisodeType:       i.IEpisodeType,
+		Season:            i.ISeason,
+		Episode:           i.IEpisode,
+	}
+	if i.ISummary != nil {
+		it.Summary = i.ISummary.Text
+	}
+	return it
+}
+
+// itemID returns the GUID of the Item, or else its Enclosure URL, Link or
+// Title, for the formats requiring an id.
+func itemID(i *Item) string {
+	switch {
+	case len(i.GUID) > 0:
+		return i.GUID
+	case i.Enclosure != nil && len(i.Enclosure.URL) > 0:
+		return i.Enclosure.URL
+	case len(i.Link) > 0:
+		return i.Link
+	}
+	return i.Title
+}
@@ -0,0 +1,123 @@
+package podcast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeJSONFeedWriterError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "desc", "Link", nil, nil)
+
+	// act
+	err := p.EncodeJSONFeed(&errWriter{})
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "e.Encode returned error")
+}
+
+func TestEncodeJSONFeedITunesFields(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "desc", &pubDate, &updatedDate)
+	p.AddSummary("summary")
+	p.AddCategory("Technology", []string{"Podcasting"})
+	p.IOwner = &podcast.Author{Name: "Jane Doe", Email: "me@janedoe.com"}
+	p.IComplete = "yes"
+	p.Items = append(p.Items, &podcast.Item{
+		GUID:             "1",
+		Title:            "t",
+		Category:         "News",
+		IDuration:        "bad:duration",
+		PubDateFormatted: createdDate.Format(time.RFC1123Z),
+		ISummary:         &podcast.ISummary{Text: "item summary"},
+		Enclosure:        &podcast.Enclosure{URL: "u", Type: podcast.MP3},
+	})
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeJSONFeed(b)
+	var f map[string]interface{}
+	jerr := json.Unmarshal(b.Bytes(), &f)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, jerr)
+	assert.EqualValues(t, true, f["expired"])
+	s := b.String()
+	assert.Contains(t, s, `"summary": "summary"`)
+	assert.Contains(t, s, `"summary": "item summary"`)
+	assert.Contains(t, s, `"subcategories": [`)
+	assert.Contains(t, s, `"email": "me@janedoe.com"`)
+	assert.Contains(t, s, `"tags": [`)
+	assert.Contains(t, s, `"date_published": "2017-02-01T08:21:52Z"`)
+	assert.NotContains(t, s, "duration_in_seconds")
+}
+
+func TestEncodeJSONFeedHubs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "desc", &pubDate, &updatedDate)
+	p.AddHub("http://hub.example.com/")
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeJSONFeed(b)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), `"hubs": [
+    {
+      "type": "WebSub",
+      "url": "http://hub.example.com/"
+    }
+  ]`)
+}
+
+func TestEncodeJSONFeedItemIDs(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "desc", &pubDate, &updatedDate)
+	p.Items = append(p.Items,
+		&podcast.Item{Title: "guid", GUID: "1", Link: "http://example.com/1"},
+		&podcast.Item{Title: "enclosure", Link: "http://example.com/2",
+			Enclosure: &podcast.Enclosure{URL: "http://example.com/2.mp3", Type: podcast.MP3}},
+		&podcast.Item{Title: "link", Link: "http://example.com/3"},
+		&podcast.Item{Title: "title"},
+	)
+	b := &bytes.Buffer{}
+
+	// act
+	err := p.EncodeJSONFeed(b)
+	var f struct {
+		Items []struct {
+			ID     string `json:"id"`
+			ITunes struct {
+				About string `json:"about"`
+			} `json:"_itunes"`
+		} `json:"items"`
+	}
+	jerr := json.Unmarshal(b.Bytes(), &f)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, jerr)
+	if assert.Len(t, f.Items, 4) {
+		assert.EqualValues(t, "1", f.Items[0].ID)
+		assert.EqualValues(t, "http://example.com/2.mp3", f.Items[1].ID)
+		assert.EqualValues(t, "http://example.com/3", f.Items[2].ID)
+		assert.EqualValues(t, "title", f.Items[3].ID)
+		assert.NotEmpty(t, f.Items[0].ITunes.About)
+	}
+}
@@ -0,0 +1,122 @@
+package podcast
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MigrateTo moves the feed to newURL, such as when changing hosts, so that
+// apps follow it to the new address without losing subscribers:
+//   * INewFeedURL is set to newURL, which Apple Podcasts uses to update
+//     the subscriptions.
+//   * AtomLink is set to newURL.
+//   * PGUID is kept as the podcast:guid of the old AtomLink, unless already
+//     set, as it must not change when the feed moves.
+//
+// The old URL should keep serving the feed, or a 301 redirect to newURL
+// such as with NewRedirect, until the apps have moved.
+//
+// The returned Findings warn of a PLocked that would make the new host
+// refuse to import the feed, and of a podcast:guid that could not be kept.
+func (p *Podcast) MigrateTo(newURL string) ([]Finding, error) {
+	u, err := url.Parse(newURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return nil, errors.New("INewFeedURL must be an http or https URL")
+	}
+
+	var warnings []Finding
+	if len(p.PGUID) == 0 {
+		if p.AtomLink != nil && len(p.AtomLink.HREF) > 0 {
+			p.PGUID = PodcastGUID(p.AtomLink.HREF)
+		} else {
+			warnings = append(warnings, Finding{
+				Severity: SeverityWarning,
+				Path:     "PGUID",
+				Rule:     "podcasting-channel-guid-migration",
+				Message: "PGUID is not set and AtomLink is missing, so the podcast:guid " +
+					"will change with the new URL",
+			})
+		}
+	}
+	if p.PLocked != nil {
+		if p.PLocked.Text == "yes" {
+			warnings = append(warnings, Finding{
+				Severity: SeverityWarning,
+				Path:     "PLocked",
+				Rule:     "podcasting-channel-locked-migration",
+				Message: "PLocked is yes, so the new host may refuse to import the feed " +
+					"until it is set to no",
+			})
+		}
+		if len(p.PLocked.Owner) == 0 {
+			warnings = append(warnings, Finding{
+				Severity: SeverityWarning,
+				Path:     "PLocked.Owner",
+				Rule:     "podcasting-channel-locked-migration",
+				Message:  "PLocked.Owner is required for the new host to verify ownership",
+			})
+		}
+	}
+
+	p.INewFeedURL = newURL
+	p.AddAtomLink(newURL)
+	return warnings, nil
+}
+
+// Redirect is http middleware answering requests for the old path of a
+// migrated feed with 301 Moved Permanently to the new URL, which podcast
+// apps follow by updating their subscriptions.
+//
+// Requests for other paths, or any after Until, are served by Next.
+type Redirect struct {
+	// From is the path of the old feed, such as "/feed.xml".
+	From string
+
+	// To is the URL of the new feed.
+	To string
+
+	// Until ends the redirects when not zero, after which requests for
+	// From are served by Next again.
+	Until time.Time
+
+	// Next serves the requests that are not redirected.  Defaults to
+	// http.NotFoundHandler.
+	Next http.Handler
+
+	// Now is the clock used for Until.  Defaults to time.Now when nil.
+	Now func() time.Time
+}
+
+// NewRedirect returns a Redirect from the path of the old feed to the URL
+// of the new feed until the time, and serving other requests with next.
+func NewRedirect(from, to string, until time.Time, next http.Handler) *Redirect {
+	return &Redirect{From: from, To: to, Until: until, Next: next}
+}
+
+// ServeHTTP implements http.Handler.
+func (rd *Redirect) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == rd.From && rd.active() {
+		http.Redirect(w, r, rd.To, http.StatusMovedPermanently)
+		return
+	}
+	next := rd.Next
+	if next == nil {
+		next = http.NotFoundHandler()
+	}
+	next.ServeHTTP(w, r)
+}
+
+// active reports whether the redirect period has not ended.
+func (rd *Redirect) active() bool {
+	if rd.Until.IsZero() {
+		return true
+	}
+	now := time.Now
+	if rd.Now != nil {
+		now = rd.Now
+	}
+	return now().Before(rd.Until)
+}
@@ -0,0 +1,152 @@
+package podcast_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateTo(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://mp3s.nashownotes.com/pc20rss.xml")
+
+	// act
+	warnings, err := p.MigrateTo("https://new.example.com/feed.xml")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.EqualValues(t, "https://new.example.com/feed.xml", p.INewFeedURL)
+	assert.EqualValues(t, "https://new.example.com/feed.xml", p.AtomLink.HREF)
+	assert.EqualValues(t, "917393e3-1b1e-5cef-ace4-edaa54e1f810", p.PGUID)
+	assert.Contains(t, p.String(), "<podcast:guid>917393e3-1b1e-5cef-ace4-edaa54e1f810</podcast:guid>")
+	assert.Contains(t, p.String(), "<itunes:new-feed-url>https://new.example.com/feed.xml</itunes:new-feed-url>")
+}
+
+func TestMigrateToKeepsPGUID(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://old.example.com/feed.xml")
+	p.PGUID = "917393e3-1b1e-5cef-ace4-edaa54e1f810"
+
+	// act
+	_, err := p.MigrateTo("https://new.example.com/feed.xml")
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "917393e3-1b1e-5cef-ace4-edaa54e1f810", p.PGUID)
+}
+
+func TestMigrateToInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range []string{"", "feed.xml", "ftp://example.com/feed.xml", "http://"} {
+		// arrange
+		p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+		p.AddAtomLink("https://old.example.com/feed.xml")
+
+		// act
+		warnings, err := p.MigrateTo(u)
+
+		// assert
+		assert.EqualError(t, err, "INewFeedURL must be an http or https URL", u)
+		assert.Nil(t, warnings)
+		assert.Empty(t, p.INewFeedURL)
+		assert.EqualValues(t, "https://old.example.com/feed.xml", p.AtomLink.HREF)
+	}
+}
+
+func TestMigrateToWarnings(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.PLocked = &podcast.PLocked{Text: "yes"}
+
+	// act
+	warnings, err := p.MigrateTo("https://new.example.com/feed.xml")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 3)
+	for _, w := range warnings {
+		assert.EqualValues(t, podcast.SeverityWarning, w.Severity)
+	}
+	assert.EqualValues(t, "PGUID", warnings[0].Path)
+	assert.EqualValues(t, "PLocked", warnings[1].Path)
+	assert.EqualValues(t, "PLocked.Owner", warnings[2].Path)
+	assert.Empty(t, p.PGUID)
+	assert.EqualValues(t, "https://new.example.com/feed.xml", p.INewFeedURL)
+}
+
+func TestMigrateToUnlocked(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("https://old.example.com/feed.xml")
+	assert.NoError(t, p.AddLocked(false, "owner@example.com"))
+
+	// act
+	warnings, err := p.MigrateTo("https://new.example.com/feed.xml")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestRedirect(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	until := pubDate.Add(30 * 24 * time.Hour)
+	h := podcast.NewRedirect("/feed.rss", "https://new.example.com/feed.xml", until,
+		podcast.NewHandler(handlerFeed))
+	h.Now = func() time.Time { return pubDate }
+
+	// act
+	w := serve(h, http.MethodGet)
+	h.Now = func() time.Time { return until }
+	after := serve(h, http.MethodGet)
+
+	// assert
+	assert.EqualValues(t, http.StatusMovedPermanently, w.Code)
+	assert.EqualValues(t, "https://new.example.com/feed.xml", w.Header().Get("Location"))
+	assert.EqualValues(t, http.StatusOK, after.Code)
+}
+
+func TestRedirectOtherPath(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewRedirect("/old.rss", "https://new.example.com/feed.xml", time.Time{}, nil)
+
+	// act
+	w := serve(h, http.MethodGet)
+
+	// assert
+	assert.EqualValues(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+}
+
+func TestRedirectForever(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	h := podcast.NewRedirect("/feed.rss", "https://new.example.com/feed.xml", time.Time{}, nil)
+
+	// act
+	w := serve(h, http.MethodHead)
+
+	// assert
+	assert.EqualValues(t, http.StatusMovedPermanently, w.Code)
+	assert.EqualValues(t, "https://new.example.com/feed.xml", w.Header().Get("Location"))
+}
@@ -0,0 +1,208 @@
+package podcast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/eduncan911/podcast/internal/id3"
+	"github.com/pkg/errors"
+)
+
+// Specifications: http://www.mp3-tech.org/programmer/frame_header.html
+//
+
+const (
+	mp3Version25 = 0
+	mp3Version2  = 2
+	mp3Version1  = 3
+
+	mp3Layer3 = 1
+	mp3Layer2 = 2
+	mp3Layer1 = 3
+
+	// mp3SyncLimit is how far past the ID3v2 tag the first frame is
+	// searched for before giving up on the file.
+	mp3SyncLimit = 64 * 1024
+)
+
+// mp3Bitrates are the kbit/s of the bitrate index for MPEG-1 layers I, II
+// and III, followed by MPEG-2 and 2.5 layer I and layers II and III.
+var mp3Bitrates = [5][16]int{
+	{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+}
+
+// mp3SampleRates are the Hz of the sample rate index by MPEG version.
+var mp3SampleRates = [4][3]int{
+	mp3Version25: {11025, 12000, 8000},
+	mp3Version2:  {22050, 24000, 16000},
+	mp3Version1:  {44100, 48000, 32000},
+}
+
+// mp3Frame is the decoded 4 byte header of an MPEG audio frame.
+type mp3Frame struct {
+	version    int
+	layer      int
+	mono       bool
+	sampleRate int
+	samples    int
+	length     int
+}
+
+// duration returns the playing time of samples at the frame's sample rate.
+func (f *mp3Frame) duration(samples int64) time.Duration {
+	return time.Duration(samples) * time.Second / time.Duration(f.sampleRate)
+}
+
+// parseMP3Frame decodes the frame header h, reporting false when h is
+// not a valid header.
+var parseMP3Frame = func(h []byte) (*mp3Frame, bool) {
+	if len(h) < 4 || h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return nil, false
+	}
+	f := &mp3Frame{
+		version: int(h[1]>>3) & 3,
+		layer:   int(h[1]>>1) & 3,
+		mono:    h[3]>>6 == 3,
+	}
+	bitrateIndex, rateIndex := int(h[2]>>4), int(h[2]>>2)&3
+	if f.version == 1 || f.layer == 0 || rateIndex == 3 {
+		return nil, false
+	}
+	table := 4
+	switch {
+	case f.version == mp3Version1:
+		table = 3 - f.layer
+	case f.layer == mp3Layer1:
+		table = 3
+	}
+	bitrate := mp3Bitrates[table][bitrateIndex] * 1000
+	if bitrate == 0 {
+		return nil, false // free format is not supported
+	}
+	f.sampleRate = mp3SampleRates[f.version][rateIndex]
+	padding := int(h[2]>>1) & 1
+
+	switch {
+	case f.layer == mp3Layer1:
+		f.samples = 384
+		f.length = (12*bitrate/f.sampleRate + padding) * 4
+	case f.layer == mp3Layer3 && f.version != mp3Version1:
+		f.samples = 576
+		f.length = 72*bitrate/f.sampleRate + padding
+	default:
+		f.samples = 1152
+		f.length = 144*bitrate/f.sampleRate + padding
+	}
+	return f, true
+}
+
+// vbrFrames returns the number of frames recorded in the Xing, Info or
+// VBRI header of the first frame, or 0 when there is none.
+var vbrFrames = func(f *mp3Frame, frame []byte) int64 {
+	side := 17
+	switch {
+	case f.version == mp3Version1 && !f.mono:
+		side = 32
+	case f.version != mp3Version1 && f.mono:
+		side = 9
+	}
+	if x := frame[minInt(4+side, len(frame)):]; len(x) >= 12 &&
+		(bytes.HasPrefix(x, []byte("Xing")) || bytes.HasPrefix(x, []byte("Info"))) {
+		if binary.BigEndian.Uint32(x[4:8])&1 == 1 {
+			return int64(binary.BigEndian.Uint32(x[8:12]))
+		}
+		return -1 // a Xing header without a frame count
+	}
+	if v := frame[minInt(36, len(frame)):]; len(v) >= 18 &&
+		bytes.HasPrefix(v, []byte("VBRI")) {
+		return int64(binary.BigEndian.Uint32(v[14:18]))
+	}
+	return 0
+}
+
+// mp3Duration returns the playing time of the MPEG audio stream, using
+// the frame count of a Xing or VBRI header when present and otherwise
+// adding up every frame, which handles VBR files without either header.
+func mp3Duration(r io.Reader) (time.Duration, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	if err := skipID3v2(br); err != nil {
+		return 0, err
+	}
+
+	// find the first frame
+	var first *mp3Frame
+	for n := 0; n < mp3SyncLimit; n++ {
+		h, err := br.Peek(4)
+		if err != nil {
+			break
+		}
+		if f, ok := parseMP3Frame(h); ok {
+			first = f
+			break
+		}
+		if _, err := br.Discard(1); err != nil {
+			return 0, err
+		}
+	}
+	if first == nil {
+		return 0, errors.New("no MPEG audio frame found")
+	}
+	frame, _ := br.Peek(first.length)
+	switch frames := vbrFrames(first, frame); {
+	case frames > 0:
+		return first.duration(frames * int64(first.samples)), nil
+	case frames < 0:
+		// the header frame is silent
+		if _, err := br.Discard(first.length); err != nil {
+			return 0, errors.New("no MPEG audio frame found")
+		}
+	}
+
+	// walk the frames until the end or a trailing ID3v1 or APE tag
+	var total time.Duration
+	for {
+		h, err := br.Peek(4)
+		if err != nil {
+			break
+		}
+		f, ok := parseMP3Frame(h)
+		if !ok || f.sampleRate != first.sampleRate {
+			break
+		}
+		total += f.duration(int64(f.samples))
+		if _, err := br.Discard(f.length); err != nil {
+			break // a truncated last frame
+		}
+	}
+	return total, nil
+}
+
+// skipID3v2 discards the ID3v2 tag at the start of the file, if any.
+func skipID3v2(br *bufio.Reader) error {
+	h, err := br.Peek(10)
+	if err != nil {
+		return nil
+	}
+	size, ok := id3.Size(h)
+	if !ok {
+		return nil
+	}
+	if _, err := br.Discard(size); err != nil {
+		return errors.Wrap(err, "truncated ID3v2 tag")
+	}
+	return nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+package podcast_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// MPEG-1 layer III 44.1kHz stereo frame headers at 128k and 64k.
+	mp3Frame128 = []byte{0xFF, 0xFB, 0x90, 0x00}
+	mp3Frame64  = []byte{0xFF, 0xFB, 0x50, 0x00}
+)
+
+func mp3Frame(header []byte, length int) []byte {
+	f := make([]byte, length)
+	copy(f, header)
+	return f
+}
+
+func writeTempFile(t *testing.T, name string, data []byte) string {
+	dir, err := ioutil.TempDir("", "podcast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAddEnclosureFromFileVBR(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var b bytes.Buffer
+	b.Write([]byte("ID3\x04\x00\x00\x00\x00\x01\x00")) // 128 byte tag
+	b.Write(make([]byte, 128))
+	for n := 0; n < 1000; n++ {
+		if n%2 == 0 {
+			b.Write(mp3Frame(mp3Frame128, 417))
+		} else {
+			b.Write(mp3Frame(mp3Frame64, 208))
+		}
+	}
+	b.Write([]byte("TAG"))
+	b.Write(make([]byte, 125))
+	path := writeTempFile(t, "episode.mp3", b.Bytes())
+	defer os.RemoveAll(filepath.Dir(path))
+	i := podcast.Item{}
+
+	// act
+	err := i.AddEnclosureFromFile("http://a.co/episode.mp3", path)
+
+	// assert
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "http://a.co/episode.mp3", i.Enclosure.URL)
+		assert.EqualValues(t, podcast.MP3, i.Enclosure.Type)
+		assert.EqualValues(t, b.Len(), i.Enclosure.Length)
+		assert.EqualValues(t, "0:26", i.IDuration) // 1000 * 1152 / 44100
+	}
+}
+
+func TestAddEnclosureFromFileXing(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	xing := mp3Frame(mp3Frame128, 417)
+	copy(xing[36:], "Xing")
+	binary.BigEndian.PutUint32(xing[40:], 1)
+	binary.BigEndian.PutUint32(xing[44:], 100000)
+	data := append(xing, mp3Frame(mp3Frame128, 417)...)
+	path := writeTempFile(t, "episode.MP3", data)
+	defer os.RemoveAll(filepath.Dir(path))
+	i := podcast.Item{}
+
+	// act
+	err := i.AddEnclosureFromFile("http://a.co/episode.mp3", path)
+
+	// assert
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "43:32", i.IDuration) // 100000 * 1152 / 44100
+	}
+}
+
+func TestAddEnclosureFromFileInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	garbage := writeTempFile(t, "garbage.mp3", []byte("not an mp3 file"))
+	defer os.RemoveAll(filepath.Dir(garbage))
+	tests := []string{
+		"episode.ogg",
+		filepath.Join(filepath.Dir(garbage), "missing.mp3"),
+		garbage,
+	}
+	for _, path := range tests {
+		i := podcast.Item{}
+
+		// act
+		err := i.AddEnclosureFromFile("http://a.co/episode.mp3", path)
+
+		// assert
+		assert.Error(t, err, path)
+		assert.Nil(t, i.Enclosure, path)
+	}
+}
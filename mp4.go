@@ -0,0 +1,462 @@
+package podcast
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://developer.apple.com/library/archive/documentation/QuickTime/QTFF/
+//
+
+const (
+	// mp4MaxMoov limits the size of the moov box read into memory.
+	mp4MaxMoov = 1 << 30
+
+	// mp4MaxSamples and mp4MaxChapterSize limit how much of a chapter
+	// track is read, as its tables come straight from the file.
+	mp4MaxSamples     = 1 << 16
+	mp4MaxChapterSize = 1 << 16
+)
+
+// MP4Info is the metadata of an MP4, M4A, M4V or MOV file read by
+// ProbeMP4.
+type MP4Info struct {
+	// Type is MOV for QuickTime movies, M4B for audiobooks, M4A for other
+	// audio-only files and otherwise M4V or MP4 by the file's brand.
+	Type     EnclosureType
+	Duration time.Duration
+
+	// Chapters are read from the QuickTime chapter track, or the Nero
+	// chapters used by many audio encoders, or nil when there are none.
+	Chapters *Chapters
+
+	// Cover is the embedded cover art, with the MIME type in CoverType.
+	Cover     []byte
+	CoverType string
+}
+
+// mp4Box is a box, or atom, of the ISO base media file format.
+type mp4Box struct {
+	typ  string
+	data []byte
+}
+
+// mp4Track is the part of a trak box needed to find the chapters.
+type mp4Track struct {
+	id         uint32
+	handler    string
+	timescale  uint32
+	chapterIDs []uint32
+	stbl       []byte
+}
+
+// ProbeMP4 reads the moov box of the ISO base media file to return the
+// duration, the EnclosureType, the chapters and the cover art.
+//
+// The chapter samples are read from wherever the chapter track stores
+// them, which is why the io.Reader must also be an io.Seeker.
+func ProbeMP4(r io.ReadSeeker) (*MP4Info, error) {
+	var brand string
+	var moov []byte
+	for {
+		typ, size, err := readMP4Header(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "podcast.ProbeMP4: r.Read returned error")
+		}
+		switch {
+		case size < 0 && typ == "moov":
+			if moov, err = ioutil.ReadAll(io.LimitReader(r, mp4MaxMoov+1)); err != nil {
+				return nil, errors.Wrap(err, "podcast.ProbeMP4: r.Read returned error")
+			}
+			if len(moov) > mp4MaxMoov {
+				return nil, errors.New("podcast.ProbeMP4: moov box is too large")
+			}
+		case size < 0:
+			// the last box extends to the end of the file
+		case typ == "ftyp" && size >= 4 && size < 1<<20:
+			b := make([]byte, size)
+			if _, err := io.ReadFull(r, b); err != nil {
+				return nil, errors.Wrap(err, "podcast.ProbeMP4: r.Read returned error")
+			}
+			brand = string(b[0:4])
+		case typ == "moov" && size <= mp4MaxMoov:
+			// the size comes from the file, so it is checked against the
+			// rest of the stream before being allocated
+			if err := mp4Remaining(r, size); err != nil {
+				return nil, err
+			}
+			moov = make([]byte, size)
+			if _, err := io.ReadFull(r, moov); err != nil {
+				return nil, errors.Wrap(err, "podcast.ProbeMP4: r.Read returned error")
+			}
+		default:
+			if _, err := r.Seek(size, io.SeekCurrent); err != nil {
+				return nil, errors.Wrap(err, "podcast.ProbeMP4: r.Seek returned error")
+			}
+		}
+		if moov != nil || size < 0 {
+			break
+		}
+	}
+	if moov == nil {
+		return nil, errors.New("podcast.ProbeMP4: moov box not found")
+	}
+
+	info := &MP4Info{}
+	if mvhd := mp4Child(moov, "mvhd"); len(mvhd) >= 20 {
+		var timescale, duration uint64
+		if mvhd[0] == 1 && len(mvhd) >= 32 {
+			timescale = uint64(binary.BigEndian.Uint32(mvhd[20:]))
+			duration = binary.BigEndian.Uint64(mvhd[24:])
+		} else {
+			timescale = uint64(binary.BigEndian.Uint32(mvhd[12:]))
+			duration = uint64(binary.BigEndian.Uint32(mvhd[16:]))
+		}
+		info.Duration = mp4Duration(duration, timescale)
+	}
+
+	var tracks []*mp4Track
+	for _, b := range mp4Children(moov) {
+		if b.typ == "trak" {
+			tracks = append(tracks, parseMP4Track(b.data))
+		}
+	}
+	info.Type = mp4EnclosureType(brand, tracks)
+
+	chapters := mp4TrackChapters(r, tracks)
+	if chapters == nil {
+		chapters = mp4NeroChapters(mp4Child(moov, "udta", "chpl"))
+	}
+	info.Chapters = chapters
+	info.Cover, info.CoverType = mp4Cover(moov)
+	return info, nil
+}
+
+// mp4Remaining returns an error when fewer than size bytes are left in r
+// after the current offset.
+func mp4Remaining(r io.Seeker, size int64) error {
+	at, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Wrap(err, "podcast.ProbeMP4: r.Seek returned error")
+	}
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Wrap(err, "podcast.ProbeMP4: r.Seek returned error")
+	}
+	if _, err := r.Seek(at, io.SeekStart); err != nil {
+		return errors.Wrap(err, "podcast.ProbeMP4: r.Seek returned error")
+	}
+	if end-at < size {
+		return errors.New("podcast.ProbeMP4: moov box is truncated")
+	}
+	return nil
+}
+
+// readMP4Header reads the size and type of the next box, returning the
+// size of its contents or -1 for a box extending to the end of the file.
+func readMP4Header(r io.Reader) (string, int64, error) {
+	h := make([]byte, 8)
+	if _, err := io.ReadFull(r, h); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return "", 0, io.EOF
+		}
+		return "", 0, err
+	}
+	size, typ := int64(binary.BigEndian.Uint32(h)), string(h[4:8])
+	switch size {
+	case 0:
+		return typ, -1, nil
+	case 1:
+		if _, err := io.ReadFull(r, h); err != nil {
+			return "", 0, err
+		}
+		size = int64(binary.BigEndian.Uint64(h)) - 8
+	}
+	if size < 8 {
+		return "", 0, errors.New("invalid box size")
+	}
+	return typ, size - 8, nil
+}
+
+// mp4Children splits b into its boxes, ignoring any truncated box.
+func mp4Children(b []byte) []mp4Box {
+	var boxes []mp4Box
+	for len(b) >= 8 {
+		size, hdr := uint64(binary.BigEndian.Uint32(b)), uint64(8)
+		typ := string(b[4:8])
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return boxes
+			}
+			size, hdr = binary.BigEndian.Uint64(b[8:]), 16
+		}
+		if size < hdr || size > uint64(len(b)) {
+			return boxes
+		}
+		boxes = append(boxes, mp4Box{typ: typ, data: b[hdr:size]})
+		b = b[size:]
+	}
+	return boxes
+}
+
+// mp4Child returns the contents of the first box found at path below b.
+func mp4Child(b []byte, path ...string) []byte {
+	for _, typ := range path {
+		var found []byte
+		for _, c := range mp4Children(b) {
+			if c.typ == typ {
+				found = c.data
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		b = found
+		if typ == "meta" && len(b) >= 8 && string(b[4:8]) != "hdlr" {
+			b = b[4:] // the ISO meta box has a version and flags
+		}
+	}
+	return b
+}
+
+func parseMP4Track(trak []byte) *mp4Track {
+	t := &mp4Track{stbl: mp4Child(trak, "mdia", "minf", "stbl")}
+	if tkhd := mp4Child(trak, "tkhd"); len(tkhd) >= 24 {
+		if tkhd[0] == 1 {
+			t.id = binary.BigEndian.Uint32(tkhd[20:])
+		} else {
+			t.id = binary.BigEndian.Uint32(tkhd[12:])
+		}
+	}
+	if hdlr := mp4Child(trak, "mdia", "hdlr"); len(hdlr) >= 12 {
+		t.handler = string(hdlr[8:12])
+	}
+	if mdhd := mp4Child(trak, "mdia", "mdhd"); len(mdhd) >= 24 {
+		if mdhd[0] == 1 {
+			t.timescale = binary.BigEndian.Uint32(mdhd[20:])
+		} else {
+			t.timescale = binary.BigEndian.Uint32(mdhd[12:])
+		}
+	}
+	chap := mp4Child(trak, "tref", "chap")
+	for n := 0; n+4 <= len(chap); n += 4 {
+		t.chapterIDs = append(t.chapterIDs, binary.BigEndian.Uint32(chap[n:]))
+	}
+	return t
+}
+
+func mp4EnclosureType(brand string, tracks []*mp4Track) EnclosureType {
+	video := false
+	for _, t := range tracks {
+		if t.handler == "vide" {
+			video = true
+		}
+	}
+	switch {
+	case brand == "qt  ":
+		return MOV
+	case brand == "M4B ":
+		return M4B
+	case !video:
+		return M4A
+	case brand == "M4V " || brand == "M4VH" || brand == "M4VP":
+		return M4V
+	}
+	return MP4
+}
+
+// mp4TrackChapters reads the samples of the text track referenced by the
+// chap reference of another track, which each hold the chapter title
+// prefixed by its length.  The samples that cannot be read are skipped.
+func mp4TrackChapters(r io.ReadSeeker, tracks []*mp4Track) *Chapters {
+	var text *mp4Track
+	for _, t := range tracks {
+		for _, id := range t.chapterIDs {
+			for _, c := range tracks {
+				if c.id == id && c.timescale > 0 {
+					text = c
+				}
+			}
+		}
+	}
+	if text == nil {
+		return nil
+	}
+
+	offsets, sizes := mp4SampleOffsets(text.stbl)
+	c := &Chapters{Version: chaptersVersion}
+	durations := mp4SampleDurations(text.stbl)
+	var start uint64
+	for n := range offsets {
+		at := start
+		if n < len(durations) {
+			start += durations[n]
+		}
+		if sizes[n] > mp4MaxChapterSize {
+			continue
+		}
+		b := make([]byte, sizes[n])
+		if _, err := r.Seek(offsets[n], io.SeekStart); err != nil {
+			continue
+		}
+		if _, err := io.ReadFull(r, b); err != nil {
+			continue
+		}
+		title := ""
+		if len(b) >= 2 {
+			l := int(binary.BigEndian.Uint16(b))
+			title = string(b[2:minInt(2+l, len(b))])
+		}
+		c.Chapters = append(c.Chapters, &Chapter{
+			StartTime: mp4Duration(at, uint64(text.timescale)).Seconds(),
+			Title:     title,
+		})
+	}
+	if len(c.Chapters) == 0 {
+		return nil
+	}
+	return c
+}
+
+// mp4SampleOffsets returns the file offset and size of every sample of
+// the sample table, from its stsz, stsc and stco or co64 boxes.
+func mp4SampleOffsets(stbl []byte) ([]int64, []uint32) {
+	var sizes []uint32
+	if stsz := mp4Child(stbl, "stsz"); len(stsz) >= 12 {
+		fixed := binary.BigEndian.Uint32(stsz[4:])
+		count := int(binary.BigEndian.Uint32(stsz[8:]))
+		for n := 0; n < count && n < mp4MaxSamples; n++ {
+			switch {
+			case fixed > 0:
+				sizes = append(sizes, fixed)
+			case 12+n*4+4 <= len(stsz):
+				sizes = append(sizes, binary.BigEndian.Uint32(stsz[12+n*4:]))
+			}
+		}
+	}
+
+	var chunks []int64
+	if stco := mp4Child(stbl, "stco"); len(stco) >= 8 {
+		for n := 8; n+4 <= len(stco); n += 4 {
+			chunks = append(chunks, int64(binary.BigEndian.Uint32(stco[n:])))
+		}
+	} else if co64 := mp4Child(stbl, "co64"); len(co64) >= 8 {
+		for n := 8; n+8 <= len(co64); n += 8 {
+			chunks = append(chunks, int64(binary.BigEndian.Uint64(co64[n:])))
+		}
+	}
+
+	// stsc entries are the first chunk, 1-based, and its samples per chunk
+	type entry struct{ first, samples int }
+	var stsc []entry
+	if b := mp4Child(stbl, "stsc"); len(b) >= 8 {
+		for n := 8; n+12 <= len(b); n += 12 {
+			stsc = append(stsc, entry{
+				int(binary.BigEndian.Uint32(b[n:])),
+				int(binary.BigEndian.Uint32(b[n+4:])),
+			})
+		}
+	}
+
+	var offsets []int64
+	sample := 0
+	for c, offset := range chunks {
+		perChunk := 1
+		for _, e := range stsc {
+			if e.first <= c+1 {
+				perChunk = e.samples
+			}
+		}
+		for n := 0; n < perChunk && sample < len(sizes); n++ {
+			offsets = append(offsets, offset)
+			offset += int64(sizes[sample])
+			sample++
+		}
+	}
+	return offsets, sizes[:len(offsets)]
+}
+
+// mp4SampleDurations expands the stts box into the duration of every
+// sample.
+func mp4SampleDurations(stbl []byte) []uint64 {
+	var durations []uint64
+	stts := mp4Child(stbl, "stts")
+	for n := 8; n+8 <= len(stts) && len(durations) < mp4MaxSamples; n += 8 {
+		count := binary.BigEndian.Uint32(stts[n:])
+		delta := uint64(binary.BigEndian.Uint32(stts[n+4:]))
+		for i := uint32(0); i < count && len(durations) < mp4MaxSamples; i++ {
+			durations = append(durations, delta)
+		}
+	}
+	return durations
+}
+
+// mp4NeroChapters reads the chpl box written by Nero and ffmpeg, whose
+// start times are in units of 100 nanoseconds.
+func mp4NeroChapters(chpl []byte) *Chapters {
+	if len(chpl) < 5 {
+		return nil
+	}
+	b := chpl[4:]
+	if chpl[0] == 1 && len(b) >= 4 {
+		b = b[4:]
+	}
+	if len(b) < 1 {
+		return nil
+	}
+	count := int(b[0])
+	b = b[1:]
+	c := &Chapters{Version: chaptersVersion}
+	for n := 0; n < count && len(b) >= 9; n++ {
+		start := binary.BigEndian.Uint64(b)
+		l := int(b[8])
+		b = b[9:]
+		if l > len(b) {
+			break
+		}
+		c.Chapters = append(c.Chapters, &Chapter{
+			StartTime: (time.Duration(start) * 100).Seconds(),
+			Title:     string(b[:l]),
+		})
+		b = b[l:]
+	}
+	if len(c.Chapters) == 0 {
+		return nil
+	}
+	return c
+}
+
+// mp4Cover returns the image and MIME type of the iTunes covr item.
+func mp4Cover(moov []byte) ([]byte, string) {
+	data := mp4Child(moov, "udta", "meta", "ilst", "covr", "data")
+	if len(data) <= 8 {
+		return nil, ""
+	}
+	switch binary.BigEndian.Uint32(data) & 0xFFFFFF {
+	case 13:
+		return data[8:], "image/jpeg"
+	case 14:
+		return data[8:], "image/png"
+	}
+	return data[8:], enclosureDefault
+}
+
+// mp4Duration converts a duration in timescale units per second.
+func mp4Duration(d, timescale uint64) time.Duration {
+	if timescale == 0 {
+		return 0
+	}
+	return time.Duration(d/timescale)*time.Second +
+		time.Duration(d%timescale)*time.Second/time.Duration(timescale)
+}
@@ -0,0 +1,234 @@
+package podcast_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func mp4Box(typ string, payload ...[]byte) []byte {
+	size := 8
+	for _, p := range payload {
+		size += len(p)
+	}
+	b := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(b, uint32(size))
+	copy(b[4:], typ)
+	for _, p := range payload {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func mp4Uint32(v ...uint32) []byte {
+	b := make([]byte, 4*len(v))
+	for n := range v {
+		binary.BigEndian.PutUint32(b[4*n:], v[n])
+	}
+	return b
+}
+
+func mp4Track(id, timescale uint32, handler string, extra ...[]byte) []byte {
+	return mp4Box("trak",
+		mp4Box("tkhd", mp4Uint32(0, 0, 0, id, 0, 0)),
+		mp4Box("mdia",
+			mp4Box("mdhd", mp4Uint32(0, 0, 0, timescale, 0, 0)),
+			mp4Box("hdlr", mp4Uint32(0, 0), []byte(handler), mp4Uint32(0, 0, 0)),
+			mp4Box("minf", extra...)))
+}
+
+// mp4Audio returns an M4A file with a QuickTime chapter track and cover.
+func mp4Audio() []byte {
+	ftyp := mp4Box("ftyp", []byte("M4A "), mp4Uint32(0), []byte("M4A isom"))
+	intro := append([]byte{0, 5}, "Intro"...)
+	main := append([]byte{0, 4}, "Main"...)
+	mdat := mp4Box("mdat", intro, main)
+	offset := uint32(len(ftyp) + 8)
+
+	audio := mp4Box("trak",
+		mp4Box("tkhd", mp4Uint32(0, 0, 0, 1, 0, 0)),
+		mp4Box("tref", mp4Box("chap", mp4Uint32(2))),
+		mp4Box("mdia",
+			mp4Box("mdhd", mp4Uint32(0, 0, 0, 44100, 0, 0)),
+			mp4Box("hdlr", mp4Uint32(0, 0), []byte("soun"), mp4Uint32(0, 0, 0))))
+	text := mp4Track(2, 1000, "text", mp4Box("stbl",
+		mp4Box("stts", mp4Uint32(0, 2, 1, 5000, 1, 60500)),
+		mp4Box("stsc", mp4Uint32(0, 1, 1, 1, 1)),
+		mp4Box("stsz", mp4Uint32(0, 0, 2, uint32(len(intro)), uint32(len(main)))),
+		mp4Box("stco", mp4Uint32(0, 2, offset, offset+uint32(len(intro))))))
+	meta := mp4Box("meta", mp4Uint32(0),
+		mp4Box("hdlr", mp4Uint32(0, 0), []byte("mdir"), mp4Uint32(0, 0, 0)),
+		mp4Box("ilst", mp4Box("covr", mp4Box("data", mp4Uint32(13, 0), []byte{0xFF, 0xD8, 0xFF}))))
+	moov := mp4Box("moov",
+		mp4Box("mvhd", mp4Uint32(0, 0, 0, 1000, 65500, 0)),
+		audio, text, mp4Box("udta", meta))
+
+	return append(append(ftyp, mdat...), moov...)
+}
+
+func TestProbeMP4Audio(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := bytes.NewReader(mp4Audio())
+
+	// act
+	info, err := podcast.ProbeMP4(r)
+
+	// assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.EqualValues(t, podcast.M4A, info.Type)
+	assert.EqualValues(t, 65500*time.Millisecond, info.Duration)
+	if assert.NotNil(t, info.Chapters) && assert.Len(t, info.Chapters.Chapters, 2) {
+		assert.EqualValues(t, 0, info.Chapters.Chapters[0].StartTime)
+		assert.EqualValues(t, "Intro", info.Chapters.Chapters[0].Title)
+		assert.EqualValues(t, 5, info.Chapters.Chapters[1].StartTime)
+		assert.EqualValues(t, "Main", info.Chapters.Chapters[1].Title)
+	}
+	assert.EqualValues(t, []byte{0xFF, 0xD8, 0xFF}, info.Cover)
+	assert.EqualValues(t, "image/jpeg", info.CoverType)
+}
+
+func TestProbeMP4NeroChapters(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	chpl := []byte{1, 0, 0, 0, 0, 0, 0, 0, 2}
+	chpl = append(chpl, 0, 0, 0, 0, 0, 0, 0, 0, 5)
+	chpl = append(chpl, "Intro"...)
+	chpl = append(chpl, 0, 0, 0, 0, 0x05, 0xF5, 0xE1, 0x00, 4) // 10s in 100ns
+	chpl = append(chpl, "Main"...)
+	data := append(
+		mp4Box("ftyp", []byte("M4V "), mp4Uint32(0)),
+		mp4Box("moov",
+			mp4Box("mvhd", []byte{1, 0, 0, 0}, make([]byte, 16), mp4Uint32(600, 0, 36000)),
+			mp4Track(1, 600, "vide"),
+			mp4Box("udta", mp4Box("chpl", chpl)))...)
+
+	// act
+	info, err := podcast.ProbeMP4(bytes.NewReader(data))
+
+	// assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.EqualValues(t, podcast.M4V, info.Type)
+	assert.EqualValues(t, time.Minute, info.Duration)
+	if assert.NotNil(t, info.Chapters) && assert.Len(t, info.Chapters.Chapters, 2) {
+		assert.EqualValues(t, "Intro", info.Chapters.Chapters[0].Title)
+		assert.EqualValues(t, 10, info.Chapters.Chapters[1].StartTime)
+	}
+	assert.Nil(t, info.Cover)
+}
+
+func TestProbeMP4Types(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := []struct {
+		brand   string
+		handler string
+		want    podcast.EnclosureType
+	}{
+		{"qt  ", "vide", podcast.MOV},
+		{"isom", "vide", podcast.MP4},
+		{"M4VP", "vide", podcast.M4V},
+		{"isom", "soun", podcast.M4A},
+	}
+	for _, tt := range tests {
+		data := append(
+			mp4Box("ftyp", []byte(tt.brand), mp4Uint32(0)),
+			mp4Box("moov", mp4Track(1, 600, tt.handler))...)
+
+		// act
+		info, err := podcast.ProbeMP4(bytes.NewReader(data))
+
+		// assert
+		if assert.NoError(t, err, tt.brand) {
+			assert.EqualValues(t, tt.want, info.Type, tt.brand)
+		}
+	}
+}
+
+func TestProbeMP4Invalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := [][]byte{
+		mp4Box("ftyp", []byte("isom"), mp4Uint32(0)),
+		[]byte("\x00\x00\x00\x04ftyp"),
+		[]byte("not an mp4 file"),
+	}
+	for _, data := range tests {
+		// act
+		info, err := podcast.ProbeMP4(bytes.NewReader(data))
+
+		// assert
+		assert.Nil(t, info, "%q", data)
+		assert.Error(t, err, "%q", data)
+	}
+}
+
+func TestProbeMP4TruncatedMoov(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	data := append(mp4Box("ftyp", []byte("isom"), mp4Uint32(0)), mp4Uint32(1<<29, 0)...)
+	copy(data[len(data)-4:], "moov")
+
+	// act
+	info, err := podcast.ProbeMP4(bytes.NewReader(data))
+
+	// assert
+	assert.Nil(t, info)
+	assert.EqualError(t, err, "podcast.ProbeMP4: moov box is truncated")
+}
+
+func TestProbeMP4ChapterOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	data := mp4Audio()
+	stco := mp4Box("stco", mp4Uint32(0, 2, 32, 39))
+	data = bytes.Replace(data, stco, mp4Box("stco", mp4Uint32(0, 2, 32, 0xFFFFFF00)), 1)
+
+	// act
+	info, err := podcast.ProbeMP4(bytes.NewReader(data))
+
+	// assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.EqualValues(t, 65500*time.Millisecond, info.Duration)
+	if assert.NotNil(t, info.Chapters) && assert.Len(t, info.Chapters.Chapters, 1) {
+		assert.EqualValues(t, "Intro", info.Chapters.Chapters[0].Title)
+	}
+}
+
+func TestAddEnclosureFromFileMP4(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	data := mp4Audio()
+	path := writeTempFile(t, "episode.m4a", data)
+	defer os.RemoveAll(filepath.Dir(path))
+	i := podcast.Item{}
+
+	// act
+	err := i.AddEnclosureFromFile("http://a.co/episode.m4a", path)
+
+	// assert
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, podcast.M4A, i.Enclosure.Type)
+		assert.EqualValues(t, len(data), i.Enclosure.Length)
+		assert.EqualValues(t, "1:06", i.IDuration)
+	}
+}
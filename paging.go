@@ -0,0 +1,171 @@
+package podcast
+
+import (
+	"encoding/xml"
+	"sort"
+	"time"
+)
+
+// Specifications: https://tools.ietf.org/html/rfc5005
+//
+
+// FHComplete marks a complete feed, one whose Items are every episode so
+// that readers may remove the ones they have that are no longer listed.
+type FHComplete struct {
+	XMLName xml.Name `xml:"fh:complete"`
+}
+
+// FHArchive marks an archived feed, one of the stable documents holding
+// the older Items of an archived feed.  See Podcast.Archives.
+type FHArchive struct {
+	XMLName xml.Name `xml:"fh:archive"`
+}
+
+// AddAtomLinkRel adds an atom:link with the rel, such as "next" or
+// "prev-archive", to the AtomLinks.  Calling this method multiple times
+// will APPEND the link to the existing list.
+//
+// The self link is set with AddAtomLink instead.
+func (p *Podcast) AddAtomLinkRel(rel, href string) {
+	if len(href) == 0 || len(rel) == 0 {
+		return
+	}
+	p.AtomLinks = append(p.AtomLinks, &AtomLink{
+		HREF: href,
+		Rel:  rel,
+		Type: "application/rss+xml",
+	})
+}
+
+// Pages splits the Items, newest first, into paged feeds of size Items
+// per RFC 5005 section 3, such as for clients that read the whole back
+// catalog a page at a time.
+//
+// The url returns the address of each page, starting at page 1, which is
+// usually the subscription feed itself.  Each page is a copy of the
+// Podcast with the AtomLink set to its url, and the AtomLinks having the
+// "first", "last", "previous" and "next" links as applicable.  A size of 0
+// or less returns a single page.
+func (p *Podcast) Pages(size int, url func(page int) string) []*Podcast {
+	items := p.itemsNewestFirst()
+	if size <= 0 || size > len(items) {
+		size = len(items)
+	}
+	count := 1
+	if size > 0 {
+		count = (len(items) + size - 1) / size
+	}
+
+	var pages []*Podcast
+	for n := 1; n <= count; n++ {
+		start := (n - 1) * size
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		pp := p.page(items[start:end], url(n))
+		pp.AddAtomLinkRel("first", url(1))
+		pp.AddAtomLinkRel("last", url(count))
+		if n > 1 {
+			pp.AddAtomLinkRel("previous", url(n-1))
+		}
+		if n < count {
+			pp.AddAtomLinkRel("next", url(n+1))
+		}
+		pages = append(pages, pp)
+	}
+	return pages
+}
+
+// Archives splits the Items into the subscription feed and the archived
+// feeds per RFC 5005 section 4, which keeps the subscription feed small
+// while the whole back catalog remains discoverable.
+//
+// The archives are numbered from 1, holding the oldest size Items, and
+// never change once full as new Items only go to the subscription feed.
+// The url returns the address of each archive.  The subscription feed
+// holds between 1 and size of the newest Items and links to the latest
+// archive with "prev-archive".  Each archive is marked with FHArchive and
+// links to the others with "prev-archive" and "next-archive", and to the
+// subscription feed, its AtomLink, with "current".
+func (p *Podcast) Archives(size int, url func(archive int) string) (*Podcast, []*Podcast) {
+	items := p.itemsNewestFirst()
+	if size <= 0 || len(items) <= size {
+		return p.page(items, ""), nil
+	}
+
+	// reverse to oldest first so that the archives stay stable
+	oldest := make([]*Item, len(items))
+	for n, i := range items {
+		oldest[len(items)-1-n] = i
+	}
+	count := (len(oldest) - 1) / size
+
+	var archives []*Podcast
+	for n := 1; n <= count; n++ {
+		block := make([]*Item, size)
+		for k := range block {
+			block[k] = oldest[n*size-1-k] // newest first within the archive
+		}
+		a := p.page(block, url(n))
+		a.FHArchive = &FHArchive{}
+		if p.AtomLink != nil {
+			a.AddAtomLinkRel("current", p.AtomLink.HREF)
+		}
+		if n > 1 {
+			a.AddAtomLinkRel("prev-archive", url(n-1))
+		}
+		if n < count {
+			a.AddAtomLinkRel("next-archive", url(n+1))
+		}
+		archives = append(archives, a)
+	}
+
+	current := p.page(items[:len(items)-count*size], "")
+	current.AddAtomLinkRel("prev-archive", url(count))
+	return current, archives
+}
+
+// page returns a copy of the Podcast with the items and, unless empty,
+// the self link set to url.  The podcast:guid stays that of the Podcast.
+func (p *Podcast) page(items []*Item, url string) *Podcast {
+	c := *p
+	c.Items = items
+	c.AtomLinks = append([]*AtomLink(nil), p.AtomLinks...)
+	if len(c.PGUID) == 0 && p.AtomLink != nil && len(p.AtomLink.HREF) > 0 {
+		c.PGUID = PodcastGUID(p.AtomLink.HREF)
+	}
+	if len(url) > 0 {
+		c.AddAtomLink(url)
+	}
+	return &c
+}
+
+// itemsNewestFirst returns the Items sorted by their publication date,
+// newest first, keeping the order of Items with the same date.
+func (p *Podcast) itemsNewestFirst() []*Item {
+	items := make(itemsByDate, len(p.Items))
+	copy(items, p.Items)
+	sort.Stable(items)
+	return items
+}
+
+type itemsByDate []*Item
+
+func (s itemsByDate) Len() int      { return len(s) }
+func (s itemsByDate) Swap(a, b int) { s[a], s[b] = s[b], s[a] }
+func (s itemsByDate) Less(a, b int) bool {
+	return itemDate(s[a]).After(itemDate(s[b]))
+}
+
+// itemDate returns the PubDate of the Item, parsing the PubDateFormatted
+// when not set, or the zero time when there is neither.
+func itemDate(i *Item) time.Time {
+	if i.PubDate != nil && !i.PubDate.IsZero() {
+		return *i.PubDate
+	}
+	if t := parseDate(i.PubDateFormatted); t != nil {
+		return *t
+	}
+	return time.Time{}
+}
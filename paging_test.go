@@ -0,0 +1,173 @@
+package podcast_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagingPodcast returns a Podcast with count Items added oldest first.
+func pagingPodcast(count int) *podcast.Podcast {
+	p := podcast.New("title", "http://a.co/", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://a.co/feed.rss")
+	for n := 1; n <= count; n++ {
+		d := createdDate.AddDate(0, 0, n)
+		_, _ = p.AddItem(podcast.Item{
+			Title:       "Episode " + strconv.Itoa(n),
+			Description: "desc",
+			Link:        "http://a.co/" + strconv.Itoa(n),
+			PubDate:     &d,
+		})
+	}
+	return &p
+}
+
+func titles(p *podcast.Podcast) string {
+	var t []string
+	for _, i := range p.Items {
+		t = append(t, strings.TrimPrefix(i.Title, "Episode "))
+	}
+	return strings.Join(t, ",")
+}
+
+func rels(p *podcast.Podcast) map[string]string {
+	m := map[string]string{}
+	for _, l := range p.AtomLinks {
+		m[l.Rel] = l.HREF
+	}
+	return m
+}
+
+func archiveURL(n int) string {
+	return fmt.Sprintf("http://a.co/archive/%d.rss", n)
+}
+
+func TestPages(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := pagingPodcast(5)
+
+	// act
+	pages := p.Pages(2, func(n int) string {
+		return "http://a.co/feed.rss?page=" + strconv.Itoa(n)
+	})
+
+	// assert
+	if !assert.Len(t, pages, 3) {
+		return
+	}
+	assert.EqualValues(t, "5,4", titles(pages[0]))
+	assert.EqualValues(t, "3,2", titles(pages[1]))
+	assert.EqualValues(t, "1", titles(pages[2]))
+	assert.EqualValues(t, "http://a.co/feed.rss?page=2", pages[1].AtomLink.HREF)
+	assert.EqualValues(t, map[string]string{
+		"first":    "http://a.co/feed.rss?page=1",
+		"last":     "http://a.co/feed.rss?page=3",
+		"previous": "http://a.co/feed.rss?page=1",
+		"next":     "http://a.co/feed.rss?page=3",
+	}, rels(pages[1]))
+	assert.NotContains(t, rels(pages[0]), "previous")
+	assert.NotContains(t, rels(pages[2]), "next")
+	assert.Len(t, p.Items, 5)
+	assert.Len(t, p.AtomLinks, 0)
+	assert.EqualValues(t, "http://a.co/feed.rss", p.AtomLink.HREF)
+}
+
+func TestPagesSingle(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := pagingPodcast(3)
+
+	// act
+	pages := p.Pages(0, func(n int) string { return "http://a.co/feed.rss" })
+
+	// assert
+	if assert.Len(t, pages, 1) {
+		assert.EqualValues(t, "3,2,1", titles(pages[0]))
+	}
+}
+
+func TestArchives(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := pagingPodcast(7)
+
+	// act
+	current, archives := p.Archives(3, archiveURL)
+
+	// assert
+	if !assert.Len(t, archives, 2) {
+		return
+	}
+	assert.EqualValues(t, "7", titles(current))
+	assert.EqualValues(t, "3,2,1", titles(archives[0]))
+	assert.EqualValues(t, "6,5,4", titles(archives[1]))
+	assert.Nil(t, current.FHArchive)
+	assert.NotNil(t, archives[0].FHArchive)
+	assert.EqualValues(t, map[string]string{"prev-archive": archiveURL(2)}, rels(current))
+	assert.EqualValues(t, map[string]string{
+		"current":      "http://a.co/feed.rss",
+		"next-archive": archiveURL(2),
+	}, rels(archives[0]))
+	assert.EqualValues(t, map[string]string{
+		"current":      "http://a.co/feed.rss",
+		"prev-archive": archiveURL(1),
+	}, rels(archives[1]))
+	assert.EqualValues(t, archiveURL(1), archives[0].AtomLink.HREF)
+}
+
+func TestArchivesStable(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	before, after := pagingPodcast(6), pagingPodcast(7)
+
+	// act
+	_, a := before.Archives(3, archiveURL)
+	_, b := after.Archives(3, archiveURL)
+
+	// assert
+	if assert.Len(t, a, 1) && assert.Len(t, b, 2) {
+		assert.EqualValues(t, titles(a[0]), titles(b[0]))
+	}
+}
+
+func TestArchivesSmall(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := pagingPodcast(3)
+
+	// act
+	current, archives := p.Archives(3, archiveURL)
+
+	// assert
+	assert.Nil(t, archives)
+	assert.EqualValues(t, "3,2,1", titles(current))
+	assert.Len(t, current.AtomLinks, 0)
+}
+
+func TestDecodeAtomLinks(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	_, archives := pagingPodcast(4).Archives(2, archiveURL)
+
+	// act
+	d, err := podcast.Decode(strings.NewReader(archives[0].String()))
+
+	// assert
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, archiveURL(1), d.AtomLink.HREF)
+		assert.Len(t, d.AtomLinks, 1)
+		assert.NotNil(t, d.FHArchive)
+		assert.EqualValues(t, archives[0].String(), d.String())
+	}
+}
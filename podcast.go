@@ -14,6 +14,11 @@ import (
 
 const (
 	pVersion = "1.3.1"
+
+	itunesNS = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	atomNS   = "http://www.w3.org/2005/Atom"
+	podNS    = "https://podcastindex.org/namespace/1.0"
+	fhNS     = "http://purl.org/syndication/history/1.0"
 )
 
 // Podcast represents a podcast.
@@ -23,7 +28,7 @@ type Podcast struct {
 	Link           string   `xml:"link"`
 	Description    string   `xml:"description"`
 	Category       string   `xml:"category,omitempty"`
-	Cloud          string   `xml:"cloud,omitempty"`
+	Cloud          string   `xml:"-"` // Deprecated: use AddCloud
 	Copyright      string   `xml:"copyright,omitempty"`
 	Docs           string   `xml:"docs,omitempty"`
 	Generator      string   `xml:"generator,omitempty"`
@@ -32,13 +37,26 @@ type Podcast struct {
 	ManagingEditor string   `xml:"managingEditor,omitempty"`
 	PubDate        string   `xml:"pubDate,omitempty"`
 	Rating         string   `xml:"rating,omitempty"`
-	SkipHours      string   `xml:"skipHours,omitempty"`
-	SkipDays       string   `xml:"skipDays,omitempty"`
+	SkipHours      string   `xml:"-"` // Deprecated: use AddSkipHours
+	SkipDays       string   `xml:"-"` // Deprecated: use AddSkipDays
 	TTL            int      `xml:"ttl,omitempty"`
 	WebMaster      string   `xml:"webMaster,omitempty"`
 	Image          *Image
 	TextInput      *TextInput
-	AtomLink       *AtomLink
+	AtomLink       *AtomLink   `xml:"-"`
+	AtomLinks      []*AtomLink `xml:"atom:link"`
+
+	// https://www.rssboard.org/rss-specification#optionalChannelElements
+	//
+	// The deprecated Cloud, SkipHours and SkipDays strings are converted
+	// when encoding unless these are set.
+	CloudFormatted     *Cloud
+	SkipHoursFormatted *SkipHours
+	SkipDaysFormatted  *SkipDays
+
+	// https://tools.ietf.org/html/rfc5005
+	FHComplete *FHComplete
+	FHArchive  *FHArchive
 
 	// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
 	IAuthor     string `xml:"itunes:author,omitempty"`
@@ -46,14 +64,29 @@ type Podcast struct {
 	ISummary    *ISummary
 	IBlock      string `xml:"itunes:block,omitempty"`
 	IImage      *IImage
-	IDuration   string  `xml:"itunes:duration,omitempty"`
-	IExplicit   string  `xml:"itunes:explicit,omitempty"`
-	IComplete   string  `xml:"itunes:complete,omitempty"`
-	INewFeedURL string  `xml:"itunes:new-feed-url,omitempty"`
-	IOwner      *Author // Author is formatted for itunes as-is
-	ICategories []*ICategory
-
-	Items []*Item
+	IDuration   string       `xml:"itunes:duration,omitempty"`
+	IExplicit   string       `xml:"itunes:explicit,omitempty"`
+	IComplete   string       `xml:"itunes:complete,omitempty"`
+	INewFeedURL string       `xml:"itunes:new-feed-url,omitempty"`
+	IType       string       `xml:"itunes:type,omitempty"`
+	IOwner      *Author      // Author is formatted for itunes as-is
+	ICategories []*ICategory `xml:"itunes:category"`
+
+	// https://github.com/Podcastindex-org/podcast-namespace
+	PGUID    string `xml:"podcast:guid,omitempty"`
+	PLocked  *PLocked
+	PFunding []*PFunding `xml:"podcast:funding"`
+	PValue   *PValue
+
+	Items []*Item `xml:"item"`
+
+	// Now is the clock used when a date is not given.  Defaults to
+	// time.Now when nil.
+	Now func() time.Time `xml:"-"`
+	// StrictDates prevents dates from being invented with the Now clock.
+	StrictDates bool `xml:"-"`
+	// StrictGUIDs prevents GUIDs from being derived from URLs.
+	StrictGUIDs bool `xml:"-"`
 
 	encode func(w io.Writer, o interface{}) error
 }
@@ -62,20 +95,58 @@ type Podcast struct {
 //
 // Nil-able fields are optional but recommended as they are formatted
 // to the expected proper formats.
+//
+// Options, such as WithClock and WithStrictDates, are applied before the
+// dates are formatted.
 func New(title, link, description string,
-	pubDate, lastBuildDate *time.Time) Podcast {
-	return Podcast{
-		Title:         title,
-		Link:          link,
-		Description:   description,
-		Generator:     fmt.Sprintf("go podcast v%s (github.com/eduncan911/podcast)", pVersion),
-		PubDate:       parseDateRFC1123Z(pubDate),
-		LastBuildDate: parseDateRFC1123Z(lastBuildDate),
-		Language:      "en-us",
+	pubDate, lastBuildDate *time.Time, options ...Option) Podcast {
+	p := Podcast{
+		Title:       title,
+		Link:        link,
+		Description: description,
+		Generator:   fmt.Sprintf("go podcast v%s (github.com/eduncan911/podcast)", pVersion),
+		Language:    "en-us",
 
 		// setup dependency (could inject later)
 		encode: encoder,
 	}
+	for _, o := range options {
+		o(&p)
+	}
+	p.PubDate = p.formatDate(pubDate)
+	p.LastBuildDate = p.formatDate(lastBuildDate)
+	return p
+}
+
+// Option configures a Podcast created with New.
+type Option func(p *Podcast)
+
+// WithClock sets the Podcast.Now clock used whenever a date is not given,
+// such as nil dates passed to New or an Item without a PubDate.
+//
+// Use a fixed clock to produce byte-identical feeds from the same inputs.
+func WithClock(now func() time.Time) Option {
+	return func(p *Podcast) {
+		p.Now = now
+	}
+}
+
+// WithStrictGUIDs sets Podcast.StrictGUIDs so that GUIDs are never
+// derived from URLs: AddItem returns an error for an Item without a GUID,
+// as moving the media to a new host would change them all.
+func WithStrictGUIDs() Option {
+	return func(p *Podcast) {
+		p.StrictGUIDs = true
+	}
+}
+
+// WithStrictDates sets Podcast.StrictDates so that dates are never
+// invented: AddItem returns an error for an Item without a PubDate, and New
+// leaves nil dates empty.
+func WithStrictDates() Option {
+	return func(p *Podcast) {
+		p.StrictDates = true
+	}
 }
 
 // AddAuthor adds the specified Author to the podcast.
@@ -110,76 +181,120 @@ func (p *Podcast) AddAtomLink(href string) {
 // list, if any, including ICategory.
 //
 // Note that Apple iTunes has a specific list of categories that only can be
-// used and will invalidate the feed if deviated from the list.  That list is
-// as follows.
+// used and will invalidate the feed if deviated from the list.  Use
+// AddAppleCategory with the Category constants to have the list enforced.
+// That list, as of Apple's 2019 update, is as follows.
 //
 //   * Arts
+//     * Books
 //     * Design
 //     * Fashion & Beauty
 //     * Food
-//     * Literature
 //     * Performing Arts
 //     * Visual Arts
 //   * Business
-//     * Business News
 //     * Careers
+//     * Entrepreneurship
 //     * Investing
-//     * Management & Marketing
-//     * Shopping
+//     * Management
+//     * Marketing
+//     * Non-Profit
 //   * Comedy
+//     * Comedy Interviews
+//     * Improv
+//     * Stand-Up
 //   * Education
-//     * Education Technology
-//     * Higher Education
-//     * K-12
-//     * Language Courses
-//     * Training
-//   * Games & Hobbies
+//     * Courses
+//     * How To
+//     * Language Learning
+//     * Self-Improvement
+//   * Fiction
+//     * Comedy Fiction
+//     * Drama
+//     * Science Fiction
+//   * Government
+//   * History
+//   * Health & Fitness
+//     * Alternative Health
+//     * Fitness
+//     * Medicine
+//     * Mental Health
+//     * Nutrition
+//     * Sexuality
+//   * Kids & Family
+//     * Education for Kids
+//     * Parenting
+//     * Pets & Animals
+//     * Stories for Kids
+//   * Leisure
+//     * Animation & Manga
 //     * Automotive
 //     * Aviation
+//     * Crafts
+//     * Games
 //     * Hobbies
-//     * Other Games
+//     * Home & Garden
 //     * Video Games
-//   * Government & Organizations
-//     * Local
-//     * National
-//     * Non-Profit
-//     * Regional
-//   * Health
-//     * Alternative Health
-//     * Fitness & Nutrition
-//     * Self-Help
-//     * Sexuality
-//   * Kids & Family
 //   * Music
-//   * News & Politics
+//     * Music Commentary
+//     * Music History
+//     * Music Interviews
+//   * News
+//     * Business News
+//     * Daily News
+//     * Entertainment News
+//     * News Commentary
+//     * Politics
+//     * Sports News
+//     * Tech News
 //   * Religion & Spirituality
 //     * Buddhism
 //     * Christianity
 //     * Hinduism
 //     * Islam
 //     * Judaism
-//     * Other
+//     * Religion
 //     * Spirituality
-//   * Science & Medicine
-//     * Medicine
+//   * Science
+//     * Astronomy
+//     * Chemistry
+//     * Earth Sciences
+//     * Life Sciences
+//     * Mathematics
 //     * Natural Sciences
+//     * Nature
+//     * Physics
 //     * Social Sciences
 //   * Society & Culture
-//     * History
+//     * Documentary
 //     * Personal Journals
 //     * Philosophy
 //     * Places & Travel
-//   * Sports & Recreation
-//     * Amateur
-//     * College & High School
-//     * Outdoor
-//     * Professional
+//     * Relationships
+//   * Sports
+//     * Baseball
+//     * Basketball
+//     * Cricket
+//     * Fantasy Sports
+//     * Football
+//     * Golf
+//     * Hockey
+//     * Rugby
+//     * Running
+//     * Soccer
+//     * Swimming
+//     * Tennis
+//     * Volleyball
+//     * Wilderness
+//     * Wrestling
 //   * Technology
-//     * Gadgets
-//     * Podcasting
-//     * Software How-To
-//     * Tech News
+//   * True Crime
 //   * TV & Film
+//     * After Shows
+//     * Film History
+//     * Film Interviews
+//     * Film Reviews
+//     * TV Reviews
 func (p *Podcast) AddCategory(category string, subCategories []string) {
 	if len(category) == 0 {
 		return
@@ -203,6 +318,14 @@ func (p *Podcast) AddCategory(category string, subCategories []string) {
 	p.ICategories = append(p.ICategories, &icat)
 }
 
+// AddShowType adds the iTunes show type.
+//
+// Serial shows are presented oldest episode first in Apple Podcasts and
+// AddItem will require an episode number on every full episode.
+func (p *Podcast) AddShowType(showType ShowType) {
+	p.IType = showType.String()
+}
+
 // AddImage adds the specified Image to the Podcast.
 //
 // Podcast feeds contain artwork that is a minimum size of
@@ -255,6 +378,12 @@ func (p *Podcast) AddImage(url string) {
 //   * Enclosure.TypeFormatted
 //   * Enclosure.LengthFormatted
 //
+// An Item without a PubDate is dated with the Podcast.Now clock, or
+// rejected when StrictDates is set (see WithStrictDates).
+//
+// iTunes seasons and episodes must be positive numbers, and every full
+// episode of a serial show (see AddShowType) requires an episode number.
+//
 // Recommendations:
 //
 //   * Just set the minimal fields: the rest get set for you.
@@ -265,28 +394,28 @@ func (p *Podcast) AddImage(url string) {
 //     https://help.apple.com/itc/podcasts_connect/#/itcb54353390
 //
 func (p *Podcast) AddItem(i Item) (int, error) {
-	// initial guards for required fields
-	if len(i.Title) == 0 || len(i.Description) == 0 {
-		return len(p.Items), errors.New("Title and Description are required")
+	if err := p.prepareItem(&i); err != nil {
+		return len(p.Items), err
 	}
-	if i.Enclosure != nil {
-		if len(i.Enclosure.URL) == 0 {
-			return len(p.Items),
-				errors.New(i.Title + ": Enclosure.URL is required")
-		}
-		if i.Enclosure.Type.String() == enclosureDefault {
-			return len(p.Items),
-				errors.New(i.Title + ": Enclosure.Type is required")
-		}
-	} else if len(i.Link) == 0 {
-		return len(p.Items),
-			errors.New(i.Title + ": Link is required when not using Enclosure")
+	p.Items = append(p.Items, &i)
+	return len(p.Items), nil
+}
+
+// prepareItem validates the Item and sets its defaults as documented by
+// AddItem, which FeedWriter.WriteItem shares.
+func (p *Podcast) prepareItem(i *Item) error {
+	// initial guards for required fields
+	if errs := p.itemErrors(i); len(errs) > 0 {
+		return errs[0]
 	}
 
 	// corrective actions and overrides
 	//
-	i.PubDateFormatted = parseDateRFC1123Z(i.PubDate)
+	i.PubDateFormatted = p.formatDate(i.PubDate)
 	i.AuthorFormatted = parseAuthorNameEmail(i.Author)
+	if len(i.GUID) == 0 && i.GUIDFormatted != nil {
+		i.GUID = i.GUIDFormatted.Value
+	}
 	if i.Enclosure != nil {
 		if len(i.GUID) == 0 {
 			i.GUID = i.Enclosure.URL // yep, GUID is the Permlink URL
@@ -303,9 +432,10 @@ func (p *Podcast) AddItem(i Item) (int, error) {
 		if len(i.Link) == 0 {
 			i.Link = i.Enclosure.URL
 		}
-	} else {
+	} else if len(i.GUID) == 0 {
 		i.GUID = i.Link // yep, GUID is the Permlink URL
 	}
+	i.GUIDFormatted = formatGUID(i)
 
 	// iTunes it
 	//
@@ -326,23 +456,22 @@ func (p *Podcast) AddItem(i Item) (int, error) {
 			i.IImage = &IImage{HREF: p.Image.URL}
 		}
 	}
-
-	p.Items = append(p.Items, &i)
-	return len(p.Items), nil
+	i.setClosedCaptioned()
+	return nil
 }
 
 // AddPubDate adds the datetime as a parsed PubDate.
 //
 // UTC time is used by default.
 func (p *Podcast) AddPubDate(datetime *time.Time) {
-	p.PubDate = parseDateRFC1123Z(datetime)
+	p.PubDate = p.formatDate(datetime)
 }
 
 // AddLastBuildDate adds the datetime as a parsed PubDate.
 //
 // UTC time is used by default.
 func (p *Podcast) AddLastBuildDate(datetime *time.Time) {
-	p.LastBuildDate = parseDateRFC1123Z(datetime)
+	p.LastBuildDate = p.formatDate(datetime)
 }
 
 // AddSubTitle adds the iTunes subtitle that is displayed with the title
@@ -393,17 +522,47 @@ func (p *Podcast) Encode(w io.Writer) error {
 		return errors.Wrap(err, "podcast.Encode: w.Write return error")
 	}
 
-	atomLink := ""
+	return p.encode(w, p.wrap(p.usesPodcastNS()))
+}
+
+// wrap returns the rss element wrapping the Podcast with the namespaces
+// it uses, declaring the podcast namespace when podcastNS is true.
+func (p *Podcast) wrap(podcastNS bool) podcastWrapper {
+	c := *p
+	channel := &c
+	channel.Items = p.formattedItems()
+
+	// the AtomLink is written first, along with the other AtomLinks
 	if p.AtomLink != nil {
-		atomLink = "http://www.w3.org/2005/Atom"
+		channel.AtomLinks = append([]*AtomLink{p.AtomLink}, p.AtomLinks...)
+		if len(channel.PGUID) == 0 && len(p.AtomLink.HREF) > 0 {
+			channel.PGUID = PodcastGUID(p.AtomLink.HREF)
+		}
+	}
+	if channel.CloudFormatted == nil && len(channel.Cloud) > 0 {
+		channel.CloudFormatted = parseCloud(channel.Cloud)
+	}
+	if channel.SkipHoursFormatted == nil && len(channel.SkipHours) > 0 {
+		channel.SkipHoursFormatted = parseSkipHours(channel.SkipHours)
+	}
+	if channel.SkipDaysFormatted == nil && len(channel.SkipDays) > 0 {
+		channel.SkipDaysFormatted = parseSkipDays(channel.SkipDays)
 	}
 	wrapped := podcastWrapper{
-		ITUNESNS: "http://www.itunes.com/dtds/podcast-1.0.dtd",
-		ATOMNS:   atomLink,
+		ITUNESNS: itunesNS,
 		Version:  "2.0",
-		Channel:  p,
+		Channel:  channel,
+	}
+	if len(channel.AtomLinks) > 0 {
+		wrapped.ATOMNS = atomNS
+	}
+	if podcastNS || len(channel.PGUID) > 0 {
+		wrapped.PODCASTNS = podNS
 	}
-	return p.encode(w, wrapped)
+	if p.FHComplete != nil || p.FHArchive != nil {
+		wrapped.FHNS = fhNS
+	}
+	return wrapped
 }
 
 // String encodes the Podcast state to a string.
@@ -426,11 +585,13 @@ func (p *Podcast) String() string {
 // }
 
 type podcastWrapper struct {
-	XMLName  xml.Name `xml:"rss"`
-	Version  string   `xml:"version,attr"`
-	ATOMNS   string   `xml:"xmlns:atom,attr,omitempty"`
-	ITUNESNS string   `xml:"xmlns:itunes,attr"`
-	Channel  *Podcast
+	XMLName   xml.Name `xml:"rss"`
+	Version   string   `xml:"version,attr"`
+	ATOMNS    string   `xml:"xmlns:atom,attr,omitempty"`
+	ITUNESNS  string   `xml:"xmlns:itunes,attr"`
+	PODCASTNS string   `xml:"xmlns:podcast,attr,omitempty"`
+	FHNS      string   `xml:"xmlns:fh,attr,omitempty"`
+	Channel   *Podcast
 }
 
 var encoder = func(w io.Writer, o interface{}) error {
@@ -442,6 +603,27 @@ var encoder = func(w io.Writer, o interface{}) error {
 	return nil
 }
 
+// now returns the current UTC time of the Podcast's clock.
+func (p *Podcast) now() time.Time {
+	if p.Now != nil {
+		return p.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// formatDate formats the datetime as RFC 1123Z, using the Podcast's clock
+// when datetime is not set unless StrictDates is enabled.
+func (p *Podcast) formatDate(datetime *time.Time) string {
+	if datetime == nil || datetime.IsZero() {
+		if p.StrictDates {
+			return ""
+		}
+		now := p.now()
+		datetime = &now
+	}
+	return parseDateRFC1123Z(datetime)
+}
+
 var parseAuthorNameEmail = func(a *Author) string {
 	var author string
 	if a != nil {
@@ -59,3 +59,48 @@ func TestParseDuration(t *testing.T) {
 	assert.Equal(t, "10:01:00", parseDuration(36060))
 	assert.Equal(t, "10:01:03", parseDuration(36063))
 }
+
+func TestParseDurationSeconds(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualValues(t, 0, parseDurationSeconds(""))
+	assert.EqualValues(t, 0, parseDurationSeconds("1:-1"))
+	assert.EqualValues(t, 40, parseDurationSeconds("40"))
+	assert.EqualValues(t, 40, parseDurationSeconds("0:40"))
+	assert.EqualValues(t, 3599, parseDurationSeconds("59:59"))
+	assert.EqualValues(t, 36063, parseDurationSeconds("10:01:03"))
+	assert.EqualValues(t, 533, parseDurationSeconds(parseDuration(533)))
+}
+
+func TestParseMP3Frame(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := []struct {
+		header  []byte
+		ok      bool
+		samples int
+		length  int
+	}{
+		{[]byte{0xFF, 0xFB, 0x90, 0x00}, true, 1152, 417}, // MPEG-1 layer III 128k 44.1kHz
+		{[]byte{0xFF, 0xFB, 0x92, 0x00}, true, 1152, 418}, // padded
+		{[]byte{0xFF, 0xF3, 0x84, 0xC0}, true, 576, 192},  // MPEG-2 layer III 64k 24kHz mono
+		{[]byte{0xFF, 0xFF, 0x90, 0x00}, true, 384, 312},  // MPEG-1 layer I 288k 44.1kHz
+		{[]byte{0xFF, 0xFD, 0x90, 0x00}, true, 1152, 522}, // MPEG-1 layer II 160k 44.1kHz
+		{[]byte{0xFF, 0xEB, 0x90, 0x00}, false, 0, 0},     // reserved version
+		{[]byte{0xFF, 0xFB, 0x00, 0x00}, false, 0, 0},     // free format
+		{[]byte{0xFF, 0xFB, 0x9C, 0x00}, false, 0, 0},     // reserved sample rate
+		{[]byte("ID3\x04"), false, 0, 0},
+	}
+	for _, tt := range tests {
+		// act
+		f, ok := parseMP3Frame(tt.header)
+
+		// assert
+		assert.EqualValues(t, tt.ok, ok, "%x", tt.header)
+		if ok {
+			assert.EqualValues(t, tt.samples, f.samples, "%x", tt.header)
+			assert.EqualValues(t, tt.length, f.length, "%x", tt.header)
+		}
+	}
+}
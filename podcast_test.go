@@ -453,3 +453,61 @@ func TestEncodeWriterError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "w.Write return error")
 }
+
+func TestNewWithClockDeterministic(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	clock := podcast.WithClock(func() time.Time { return createdDate })
+	build := func() string {
+		p := podcast.New("title", "link", "description", nil, nil, clock)
+		i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+		if _, err := p.AddItem(i); err != nil {
+			t.Fatal(err)
+		}
+		return p.String()
+	}
+
+	// act
+	first, second := build(), build()
+
+	// assert
+	assert.EqualValues(t, first, second)
+	assert.Contains(t, first, createdDate.Format(time.RFC1123Z))
+}
+
+func TestAddItemStrictDatesZeroPubDate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil,
+		podcast.WithStrictDates())
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.PubDate = &time.Time{}
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "PubDate is required")
+	assert.Len(t, p.PubDate, 0)
+	assert.Len(t, p.LastBuildDate, 0)
+}
+
+func TestValidateStrictDates(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Items = []*podcast.Item{{Title: "t", Description: "d", Link: "l", GUID: "1"}}
+	p.StrictDates = true
+
+	// act
+	r := p.Validate()
+
+	// assert
+	assert.True(t, r.HasErrors())
+	assert.Contains(t, r.Err().Error(), "Items[0].PubDate")
+}
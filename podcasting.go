@@ -0,0 +1,258 @@
+package podcast
+
+import (
+	"encoding/xml"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://github.com/Podcastindex-org/podcast-namespace
+//
+
+// PLocked tells other podcast platforms whether they are allowed to import
+// the feed.  The Owner email is used to verify ownership when moving hosts.
+type PLocked struct {
+	XMLName xml.Name `xml:"podcast:locked"`
+	Owner   string   `xml:"owner,attr"`
+	Text    string   `xml:",chardata"`
+}
+
+// PFunding links to a donation or membership page for the podcast.
+type PFunding struct {
+	XMLName xml.Name `xml:"podcast:funding"`
+	URL     string   `xml:"url,attr"`
+	Text    string   `xml:",chardata"`
+}
+
+// PTranscript links to a transcript or closed captions file for an episode.
+type PTranscript struct {
+	XMLName  xml.Name `xml:"podcast:transcript"`
+	URL      string   `xml:"url,attr"`
+	Type     string   `xml:"type,attr"`
+	Language string   `xml:"language,attr,omitempty"`
+	Rel      string   `xml:"rel,attr,omitempty"`
+}
+
+// PChapters links to the chapters file for an episode.
+type PChapters struct {
+	XMLName xml.Name `xml:"podcast:chapters"`
+	URL     string   `xml:"url,attr"`
+	Type    string   `xml:"type,attr"`
+}
+
+// PValue declares how listeners can send cryptocurrency payments, such as
+// streaming sats over the lightning network, to the podcast or episode.
+type PValue struct {
+	XMLName    xml.Name           `xml:"podcast:value"`
+	Type       string             `xml:"type,attr"`
+	Method     string             `xml:"method,attr"`
+	Suggested  string             `xml:"suggested,attr,omitempty"`
+	Recipients []*PValueRecipient `xml:"podcast:valueRecipient"`
+}
+
+// PValueRecipient is a destination of the payments of a PValue.
+//
+// Split is the number of shares of each payment sent to the recipient.
+// Fee recipients instead take Split as a percentage off the top before the
+// shares are divided.
+type PValueRecipient struct {
+	XMLName     xml.Name `xml:"podcast:valueRecipient"`
+	Name        string   `xml:"name,attr,omitempty"`
+	CustomKey   string   `xml:"customKey,attr,omitempty"`
+	CustomValue string   `xml:"customValue,attr,omitempty"`
+	Type        string   `xml:"type,attr"`
+	Address     string   `xml:"address,attr"`
+	Split       int      `xml:"split,attr"`
+	Fee         bool     `xml:"fee,attr,omitempty"`
+}
+
+// AddLocked adds the podcast:locked tag which, when locked is true, asks
+// other platforms to refuse importing the feed.
+//
+// The owner email is required.
+func (p *Podcast) AddLocked(locked bool, owner string) error {
+	if len(owner) == 0 {
+		return errors.New("PLocked.Owner is required")
+	}
+	text := "no"
+	if locked {
+		text = "yes"
+	}
+	p.PLocked = &PLocked{Owner: owner, Text: text}
+	return nil
+}
+
+// AddFunding adds a podcast:funding link.  Calling this method multiple
+// times will APPEND the link to the existing list, if any.
+//
+// The url is required.  The text is limited to 128 characters and will be
+// truncated if too long.
+func (p *Podcast) AddFunding(url, text string) error {
+	if len(url) == 0 {
+		return errors.New("PFunding.URL is required")
+	}
+	if utf8.RuneCountInString(text) > 128 {
+		text = string([]rune(text)[0:128])
+	}
+	p.PFunding = append(p.PFunding, &PFunding{URL: url, Text: text})
+	return nil
+}
+
+// AddTranscript adds a podcast:transcript link to the Item.  Calling this
+// method multiple times will APPEND the transcript to the existing list,
+// such as when offering several formats or languages.
+//
+// The url and transcriptType, a MIME type such as "text/vtt", are
+// required.  The language and rel ("captions") are optional.
+//
+// Captions attached to a video Enclosure also set IIsClosedCaptioned.
+// Use TranscriptSRT, TranscriptVTT, TranscriptJSON or TranscriptHTML for
+// files written with Transcript.Encode.
+func (i *Item) AddTranscript(url, transcriptType, language, rel string) error {
+	t := &PTranscript{
+		URL:      url,
+		Type:     transcriptType,
+		Language: language,
+		Rel:      rel,
+	}
+	if err := validateTranscript(t); err != nil {
+		return err
+	}
+	i.PTranscripts = append(i.PTranscripts, t)
+	i.setClosedCaptioned()
+	return nil
+}
+
+// AddChapters adds the podcast:chapters link to the Item.
+//
+// The url and chaptersType, a MIME type such as
+// "application/json+chapters", are required.
+func (i *Item) AddChapters(url, chaptersType string) error {
+	c := &PChapters{URL: url, Type: chaptersType}
+	if err := validateChapters(c); err != nil {
+		return err
+	}
+	i.PChapters = c
+	return nil
+}
+
+// AddValue adds the podcast:value block for the whole Podcast, such as
+// valueType "lightning" with method "keysend".
+//
+// The valueType, method and at least one recipient are required.  Every
+// recipient requires a Type and Address, splits must not be negative, the
+// shares must add up to more than zero and the fees to less than 100%.
+func (p *Podcast) AddValue(valueType, method string,
+	recipients []PValueRecipient) error {
+	v, err := newValue(valueType, method, recipients)
+	if err != nil {
+		return err
+	}
+	p.PValue = v
+	return nil
+}
+
+// AddValue adds the podcast:value block for the Item, which overrides
+// the Podcast's value block for this episode.  See Podcast.AddValue for the
+// requirements.
+func (i *Item) AddValue(valueType, method string,
+	recipients []PValueRecipient) error {
+	v, err := newValue(valueType, method, recipients)
+	if err != nil {
+		return err
+	}
+	i.PValue = v
+	return nil
+}
+
+func newValue(valueType, method string,
+	recipients []PValueRecipient) (*PValue, error) {
+	v := &PValue{Type: valueType, Method: method}
+	for n := range recipients {
+		r := recipients[n]
+		v.Recipients = append(v.Recipients, &r)
+	}
+	if err := validateValue(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func validateValue(v *PValue) error {
+	if len(v.Type) == 0 || len(v.Method) == 0 {
+		return errors.New("PValue.Type and PValue.Method are required")
+	}
+	if len(v.Recipients) == 0 {
+		return errors.New("PValue.Recipients requires at least one recipient")
+	}
+	shares, fees := 0, 0
+	for _, r := range v.Recipients {
+		if len(r.Type) == 0 || len(r.Address) == 0 {
+			return errors.New("PValueRecipient.Type and PValueRecipient.Address are required")
+		}
+		if r.Split < 0 {
+			return errors.New("PValueRecipient.Split must not be negative")
+		}
+		if r.Fee {
+			fees += r.Split
+		} else {
+			shares += r.Split
+		}
+	}
+	if shares == 0 {
+		return errors.New("PValueRecipient.Split shares must add up to more than 0")
+	}
+	if fees >= 100 {
+		return errors.New("PValueRecipient.Split fees must add up to less than 100")
+	}
+	return nil
+}
+
+// setClosedCaptioned sets IIsClosedCaptioned when a captions transcript
+// is attached to a video Enclosure.
+func (i *Item) setClosedCaptioned() {
+	if i.Enclosure == nil || !i.Enclosure.Type.isVideo() {
+		return
+	}
+	for _, t := range i.PTranscripts {
+		if t.Rel == "captions" {
+			i.IIsClosedCaptioned = "Yes"
+			return
+		}
+	}
+}
+
+func validateTranscript(t *PTranscript) error {
+	if len(t.URL) == 0 {
+		return errors.New("PTranscript.URL is required")
+	}
+	if len(t.Type) == 0 {
+		return errors.New("PTranscript.Type is required")
+	}
+	return nil
+}
+
+func validateChapters(c *PChapters) error {
+	if len(c.URL) == 0 {
+		return errors.New("PChapters.URL is required")
+	}
+	if len(c.Type) == 0 {
+		return errors.New("PChapters.Type is required")
+	}
+	return nil
+}
+
+// usesPodcastNS reports whether any podcast namespace tag is set, in which
+// case the namespace must be declared on the rss element.
+func (p *Podcast) usesPodcastNS() bool {
+	if p.PLocked != nil || len(p.PFunding) > 0 || p.PValue != nil {
+		return true
+	}
+	for _, i := range p.Items {
+		if len(i.PTranscripts) > 0 || i.PChapters != nil || i.PValue != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package podcast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddLockedOwnerEmpty(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddLocked(true, "")
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "PLocked.Owner is required")
+	assert.Nil(t, p.PLocked)
+}
+
+func TestAddLockedNo(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddLocked(false, "me@janedoe.com")
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, "no", p.PLocked.Text)
+	assert.Contains(t, p.String(), `<podcast:locked owner="me@janedoe.com">no</podcast:locked>`)
+	assert.Contains(t, p.String(), `xmlns:podcast="https://podcastindex.org/namespace/1.0"`)
+}
+
+func TestAddFundingURLEmpty(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddFunding("", "Support the show!")
+
+	// assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "PFunding.URL is required")
+	assert.Len(t, p.PFunding, 0)
+}
+
+func TestAddFundingTextTooLong(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddFunding("https://example.com/donate", strings.Repeat("abc ", 40))
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, p.PFunding[0].Text, 128)
+}
+
+func TestPodcastNamespaceNotUsed(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	s := p.String()
+
+	// assert
+	assert.NotContains(t, s, "xmlns:podcast")
+}
+
+func TestItemAddTranscriptRequired(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+
+	// act
+	errURL := i.AddTranscript("", "text/vtt", "", "")
+	errType := i.AddTranscript("http://a.co/1.vtt", "", "", "")
+
+	// assert
+	assert.Contains(t, errURL.Error(), "PTranscript.URL is required")
+	assert.Contains(t, errType.Error(), "PTranscript.Type is required")
+	assert.Len(t, i.PTranscripts, 0)
+}
+
+func TestItemAddChaptersRequired(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+
+	// act
+	errURL := i.AddChapters("", "application/json+chapters")
+	errType := i.AddChapters("http://a.co/1.json", "")
+
+	// assert
+	assert.Contains(t, errURL.Error(), "PChapters.URL is required")
+	assert.Contains(t, errType.Error(), "PChapters.Type is required")
+	assert.Nil(t, i.PChapters)
+}
+
+func TestAddItemInvalidTranscript(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.PTranscripts = append(i.PTranscripts, &podcast.PTranscript{URL: "http://a.co/1.vtt"})
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "title: PTranscript.Type is required")
+}
+
+func TestAddItemInvalidChapters(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.PChapters = &podcast.PChapters{Type: "application/json+chapters"}
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "title: PChapters.URL is required")
+}
+
+func TestAddValueInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	node := func(split int, fee bool) podcast.PValueRecipient {
+		return podcast.PValueRecipient{Type: "node", Address: "02d5", Split: split, Fee: fee}
+	}
+	tests := []struct {
+		name       string
+		valueType  string
+		method     string
+		recipients []podcast.PValueRecipient
+		err        string
+	}{
+		{"type", "", "keysend", []podcast.PValueRecipient{node(100, false)}, "PValue.Type and PValue.Method are required"},
+		{"method", "lightning", "", []podcast.PValueRecipient{node(100, false)}, "PValue.Type and PValue.Method are required"},
+		{"recipients", "lightning", "keysend", nil, "PValue.Recipients requires at least one recipient"},
+		{"address", "lightning", "keysend", []podcast.PValueRecipient{{Type: "node", Split: 100}}, "PValueRecipient.Type and PValueRecipient.Address are required"},
+		{"negative", "lightning", "keysend", []podcast.PValueRecipient{node(100, false), node(-1, false)}, "PValueRecipient.Split must not be negative"},
+		{"shares", "lightning", "keysend", []podcast.PValueRecipient{node(0, false), node(1, true)}, "PValueRecipient.Split shares must add up to more than 0"},
+		{"fees", "lightning", "keysend", []podcast.PValueRecipient{node(100, false), node(60, true), node(40, true)}, "PValueRecipient.Split fees must add up to less than 100"},
+	}
+	for _, tt := range tests {
+		p := podcast.New("title", "link", "description", nil, nil)
+		i := podcast.Item{}
+
+		// act
+		perr := p.AddValue(tt.valueType, tt.method, tt.recipients)
+		ierr := i.AddValue(tt.valueType, tt.method, tt.recipients)
+
+		// assert
+		if assert.Error(t, perr, tt.name) && assert.Error(t, ierr, tt.name) {
+			assert.EqualValues(t, tt.err, perr.Error(), tt.name)
+			assert.EqualValues(t, tt.err, ierr.Error(), tt.name)
+		}
+		assert.Nil(t, p.PValue, tt.name)
+		assert.Nil(t, i.PValue, tt.name)
+	}
+}
+
+func TestAddItemInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	i.PValue = &podcast.PValue{Type: "lightning", Method: "keysend"}
+
+	// act
+	added, err := p.AddItem(i)
+
+	// assert
+	assert.EqualValues(t, 0, added)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "title: PValue.Recipients requires at least one recipient")
+}
+
+func TestDecodePodcastNamespace(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", &pubDate, &updatedDate)
+	_ = p.AddLocked(true, "me@janedoe.com")
+	_ = p.AddFunding("https://example.com/donate", "Donate")
+	i := podcast.Item{Title: "title", Description: "desc", Link: "http://a.co/"}
+	_ = i.AddTranscript("http://a.co/1.vtt", "text/vtt", "en", "captions")
+	_ = i.AddChapters("http://a.co/1.json", "application/json+chapters")
+	_ = i.AddValue("lightning", "keysend", []podcast.PValueRecipient{
+		{Name: "Host", Type: "node", Address: "02d5", Split: 99},
+		{Name: "App", Type: "node", Address: "03ae", Split: 1, Fee: true},
+	})
+	_, _ = p.AddItem(i)
+
+	// act
+	d, err := podcast.Decode(strings.NewReader(p.String()))
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, p.String(), d.String())
+	assert.EqualValues(t, "yes", d.PLocked.Text)
+	assert.Len(t, d.Items[0].PTranscripts, 1)
+	assert.EqualValues(t, "http://a.co/1.json", d.Items[0].PChapters.URL)
+	if assert.NotNil(t, d.Items[0].PValue) {
+		assert.Len(t, d.Items[0].PValue.Recipients, 2)
+		assert.True(t, d.Items[0].PValue.Recipients[1].Fee)
+	}
+}
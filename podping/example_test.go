@@ -0,0 +1,39 @@
+package podping_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/eduncan911/podcast"
+	"github.com/eduncan911/podcast/podping"
+)
+
+func ExampleNotifier() {
+	// a podping.cloud compatible endpoint
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.FormValue("reason"), r.FormValue("medium"), r.FormValue("url"))
+	}))
+	defer endpoint.Close()
+
+	n := podping.NewNotifier(&podping.HTTPTransport{
+		Endpoint:  endpoint.URL,
+		Token:     "token issued by podping.cloud",
+		UserAgent: "example/1.0",
+	})
+
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.AddAtomLink("http://example.com/feed.rss")
+
+	// repeated notifications for the same feed are sent once
+	for k := 0; k < 3; k++ {
+		if err := n.Notify(&p, podping.ReasonUpdate); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if err := n.Flush(); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// update podcast http://example.com/feed.rss
+}
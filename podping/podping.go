@@ -0,0 +1,233 @@
+// Package podping announces podcast feed updates to the Podping network
+// of the Podcasting 2.0 ecosystem, alongside WebSub hubs, so that apps
+// fetch new episodes without polling.
+//
+// A Notifier queues the notifications, dropping repeats for the same feed
+// within a window, and sends them in batches through a Transport such as
+// the HTTPTransport for podping.cloud compatible endpoints.
+//
+// Specifications: https://github.com/Podcastindex-org/podping-hivewriter
+package podping
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the Notification payload.
+const Version = "1.0"
+
+// Reason is why the feed is announced.
+type Reason string
+
+// Reasons of a Notification.
+const (
+	ReasonUpdate  Reason = "update"
+	ReasonLive    Reason = "live"
+	ReasonLiveEnd Reason = "liveEnd"
+)
+
+// Medium is the kind of content of the feed, matching podcast:medium.
+type Medium string
+
+// Mediums of a Notification.
+const (
+	MediumPodcast    Medium = "podcast"
+	MediumMusic      Medium = "music"
+	MediumVideo      Medium = "video"
+	MediumFilm       Medium = "film"
+	MediumAudiobook  Medium = "audiobook"
+	MediumNewsletter Medium = "newsletter"
+	MediumBlog       Medium = "blog"
+)
+
+// Notification is the payload announcing that the feeds at the IRIs have
+// changed for the Reason.
+type Notification struct {
+	Version string   `json:"version"`
+	Medium  Medium   `json:"medium"`
+	Reason  Reason   `json:"reason"`
+	IRIs    []string `json:"iris"`
+}
+
+// Transport sends a Notification, with all of its IRIs, to the Podping
+// network.
+type Transport interface {
+	Send(n *Notification) error
+}
+
+// Notifier batches and deduplicates notifications before sending them
+// with the Transport.  It is safe for concurrent use.
+//
+// Notifications are queued until BatchSize IRIs of the same Medium and
+// Reason are pending, or Flush is called, such as from a time.Ticker.
+// A feed notified again with the same Reason while pending, or within the
+// Window of its last sent notification, is ignored, as apps fetch the
+// latest version anyway.  The notifications the Transport fails to send
+// are queued again for the next Flush.
+type Notifier struct {
+	// Transport sends the notifications.
+	Transport Transport
+
+	// Window is how long repeated notifications are ignored for.
+	Window time.Duration
+
+	// BatchSize is the number of IRIs sent in one Notification.
+	BatchSize int
+
+	// Now is the clock used for the Window.  Defaults to time.Now when
+	// nil.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	pending []*Notification
+	last    map[string]time.Time
+}
+
+// NewNotifier returns a Notifier sending through the Transport in batches
+// of up to 100 IRIs, ignoring repeats within 3 minutes.
+func NewNotifier(t Transport) *Notifier {
+	return &Notifier{
+		Transport: t,
+		Window:    3 * time.Minute,
+		BatchSize: 100,
+	}
+}
+
+// Notify announces that the Podcast, identified by its AtomLink self URL,
+// has changed for the reason.
+func (n *Notifier) Notify(p *podcast.Podcast, reason Reason) error {
+	if p.AtomLink == nil || len(p.AtomLink.HREF) == 0 {
+		return errors.New("podping.Notify: AtomLink is required")
+	}
+	return n.NotifyURL(p.AtomLink.HREF, MediumPodcast, reason)
+}
+
+// NotifyURL announces that the feed at iri has changed for the reason.
+// The notification is sent once the batch of its medium and reason is
+// full, returning the error of the Transport.
+func (n *Notifier) NotifyURL(iri string, medium Medium, reason Reason) error {
+	if len(iri) == 0 {
+		return errors.New("podping.NotifyURL: iri is required")
+	}
+	if len(medium) == 0 || len(reason) == 0 {
+		return errors.New("podping.NotifyURL: medium and reason are required")
+	}
+
+	n.mu.Lock()
+	now := n.now()
+	if t, ok := n.last[string(reason)+" "+iri]; ok && now.Sub(t) < n.Window {
+		n.mu.Unlock()
+		return nil
+	}
+	n.forget(now)
+	batch, added := n.queue(medium, reason, iri)
+	var full []*Notification
+	if added && len(batch.IRIs) >= n.BatchSize {
+		full = n.take(batch)
+	}
+	n.mu.Unlock()
+
+	return n.send(full)
+}
+
+// Flush sends the pending notifications, returning the first error of the
+// Transport.
+func (n *Notifier) Flush() error {
+	n.mu.Lock()
+	pending := n.pending
+	n.pending = nil
+	n.mu.Unlock()
+
+	return n.send(pending)
+}
+
+// queue adds the IRIs that are not already pending to the batch of the
+// medium and reason, returning the batch and whether any were added.
+func (n *Notifier) queue(medium Medium, reason Reason, iris ...string) (*Notification, bool) {
+	var batch *Notification
+	for _, b := range n.pending {
+		if b.Medium == medium && b.Reason == reason {
+			batch = b
+			break
+		}
+	}
+	if batch == nil {
+		batch = &Notification{Version: Version, Medium: medium, Reason: reason}
+		n.pending = append(n.pending, batch)
+	}
+	added := false
+	for _, iri := range iris {
+		if !containsIRI(batch.IRIs, iri) {
+			batch.IRIs = append(batch.IRIs, iri)
+			added = true
+		}
+	}
+	return batch, added
+}
+
+func containsIRI(iris []string, iri string) bool {
+	for _, i := range iris {
+		if i == iri {
+			return true
+		}
+	}
+	return false
+}
+
+// take removes the batch from the pending notifications.
+func (n *Notifier) take(batch *Notification) []*Notification {
+	for k, b := range n.pending {
+		if b == batch {
+			n.pending = append(n.pending[:k], n.pending[k+1:]...)
+			break
+		}
+	}
+	return []*Notification{batch}
+}
+
+// forget drops the notifications that are past the Window, so that the
+// map stays the size of the recently changed feeds.
+func (n *Notifier) forget(now time.Time) {
+	for key, t := range n.last {
+		if now.Sub(t) >= n.Window {
+			delete(n.last, key)
+		}
+	}
+}
+
+// send sends the batches, starting the Window of their IRIs once sent.
+// The batches that failed are queued again for the next Flush.
+func (n *Notifier) send(batches []*Notification) error {
+	var first error
+	for _, b := range batches {
+		err := n.Transport.Send(b)
+		n.mu.Lock()
+		if err != nil {
+			n.queue(b.Medium, b.Reason, b.IRIs...)
+		} else {
+			if n.last == nil {
+				n.last = make(map[string]time.Time)
+			}
+			now := n.now()
+			for _, iri := range b.IRIs {
+				n.last[string(b.Reason)+" "+iri] = now
+			}
+		}
+		n.mu.Unlock()
+		if err != nil && first == nil {
+			first = errors.Wrap(err, "podping: Transport.Send returned error")
+		}
+	}
+	return first
+}
+
+func (n *Notifier) now() time.Time {
+	if n.Now != nil {
+		return n.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,162 @@
+package podping_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/eduncan911/podcast/podping"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	mu   sync.Mutex
+	sent []*podping.Notification
+	err  error
+}
+
+func (r *recorder) Send(n *podping.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, n)
+	return r.err
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func TestNotifierBatches(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := &recorder{}
+	n := podping.NewNotifier(r)
+	n.BatchSize = 2
+
+	// act
+	assert.NoError(t, n.NotifyURL("http://example.com/1.rss", podping.MediumPodcast, podping.ReasonUpdate))
+	assert.NoError(t, n.NotifyURL("http://example.com/2.rss", podping.MediumMusic, podping.ReasonUpdate))
+	assert.Len(t, r.sent, 0)
+	assert.NoError(t, n.NotifyURL("http://example.com/3.rss", podping.MediumPodcast, podping.ReasonUpdate))
+
+	// assert
+	assert.Len(t, r.sent, 1)
+	assert.EqualValues(t, &podping.Notification{
+		Version: "1.0",
+		Medium:  podping.MediumPodcast,
+		Reason:  podping.ReasonUpdate,
+		IRIs:    []string{"http://example.com/1.rss", "http://example.com/3.rss"},
+	}, r.sent[0])
+	assert.NoError(t, n.Flush())
+	assert.Len(t, r.sent, 2)
+	assert.EqualValues(t, podping.MediumMusic, r.sent[1].Medium)
+	assert.NoError(t, n.Flush())
+	assert.Len(t, r.sent, 2)
+}
+
+func TestNotifierDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := &recorder{}
+	c := &clock{t: time.Date(2017, 2, 4, 8, 21, 52, 0, time.UTC)}
+	n := podping.NewNotifier(r)
+	n.Now = c.now
+	iri := "http://example.com/feed.rss"
+
+	// act
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonLive))
+	assert.NoError(t, n.Flush())
+	c.t = c.t.Add(time.Minute)
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	assert.NoError(t, n.Flush())
+	c.t = c.t.Add(3 * time.Minute)
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	err := n.Flush()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, r.sent, 3)
+	assert.EqualValues(t, []string{iri}, r.sent[0].IRIs)
+	assert.EqualValues(t, podping.ReasonLive, r.sent[1].Reason)
+	assert.EqualValues(t, []string{iri}, r.sent[1].IRIs)
+	assert.EqualValues(t, podping.ReasonUpdate, r.sent[2].Reason)
+	assert.EqualValues(t, []string{iri}, r.sent[2].IRIs)
+}
+
+func TestNotifyAtomLinkRequired(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	n := podping.NewNotifier(&recorder{})
+
+	// act
+	err := n.Notify(&p, podping.ReasonUpdate)
+
+	// assert
+	assert.EqualError(t, err, "podping.Notify: AtomLink is required")
+}
+
+func TestNotifyURLInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	n := podping.NewNotifier(&recorder{})
+
+	// act
+	err1 := n.NotifyURL("", podping.MediumPodcast, podping.ReasonUpdate)
+	err2 := n.NotifyURL("http://example.com/feed.rss", "", podping.ReasonUpdate)
+
+	// assert
+	assert.EqualError(t, err1, "podping.NotifyURL: iri is required")
+	assert.EqualError(t, err2, "podping.NotifyURL: medium and reason are required")
+}
+
+func TestNotifierTransportError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := &recorder{err: errors.New("offline")}
+	n := podping.NewNotifier(r)
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.AddAtomLink("http://example.com/feed.rss")
+	assert.NoError(t, n.Notify(&p, podping.ReasonUpdate))
+
+	// act
+	err := n.Flush()
+
+	// assert
+	assert.EqualError(t, err, "podping: Transport.Send returned error: offline")
+}
+
+func TestNotifierTransportErrorRetried(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	r := &recorder{err: errors.New("offline")}
+	n := podping.NewNotifier(r)
+	n.BatchSize = 2
+	iri := "http://example.com/1.rss"
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	err := n.NotifyURL("http://example.com/2.rss", podping.MediumPodcast, podping.ReasonUpdate)
+	assert.EqualError(t, err, "podping: Transport.Send returned error: offline")
+
+	// act
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	r.err = nil
+	err = n.Flush()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, r.sent, 2)
+	assert.EqualValues(t, []string{iri, "http://example.com/2.rss"}, r.sent[1].IRIs)
+	assert.NoError(t, n.NotifyURL(iri, podping.MediumPodcast, podping.ReasonUpdate))
+	assert.NoError(t, n.Flush())
+	assert.Len(t, r.sent, 2, "sent within the Window")
+}
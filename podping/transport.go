@@ -0,0 +1,71 @@
+package podping
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultEndpoint is the podping.cloud HTTP endpoint.
+const DefaultEndpoint = "https://podping.cloud/"
+
+// HTTPTransport sends notifications to a podping.cloud compatible
+// endpoint, which takes one IRI per GET request along with the reason
+// and medium, authorized by the Token issued to the publisher.
+type HTTPTransport struct {
+	// Endpoint defaults to DefaultEndpoint.
+	Endpoint string
+
+	// Token is sent as the Authorization header.
+	Token string
+
+	// UserAgent identifies the publisher to the endpoint.
+	UserAgent string
+
+	// Client sends the requests, defaulting to http.DefaultClient.
+	Client *http.Client
+}
+
+// Send implements Transport, requesting each IRI of the Notification in
+// turn and stopping at the first failure.
+func (t *HTTPTransport) Send(n *Notification) error {
+	endpoint, client := t.Endpoint, t.Client
+	if len(endpoint) == 0 {
+		endpoint = DefaultEndpoint
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	for _, iri := range n.IRIs {
+		q := url.Values{
+			"url":    {iri},
+			"reason": {string(n.Reason)},
+			"medium": {string(n.Medium)},
+		}
+		req, err := http.NewRequest(http.MethodGet, endpoint+"?"+q.Encode(), nil)
+		if err != nil {
+			return errors.Wrap(err, "podping.HTTPTransport.Send: http.NewRequest returned error")
+		}
+		if len(t.Token) > 0 {
+			req.Header.Set("Authorization", t.Token)
+		}
+		if len(t.UserAgent) > 0 {
+			req.Header.Set("User-Agent", t.UserAgent)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "podping.HTTPTransport.Send: client.Do returned error")
+		}
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return errors.New("podping.HTTPTransport.Send: " + iri +
+				": endpoint returned " + strconv.Itoa(resp.StatusCode))
+		}
+	}
+	return nil
+}
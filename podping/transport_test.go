@@ -0,0 +1,57 @@
+package podping_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eduncan911/podcast/podping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPTransportSend(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var got []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "test/1.0", r.Header.Get("User-Agent"))
+		got = append(got, r.URL.RawQuery)
+	}))
+	defer s.Close()
+	tr := &podping.HTTPTransport{Endpoint: s.URL, Token: "secret", UserAgent: "test/1.0"}
+
+	// act
+	err := tr.Send(&podping.Notification{
+		Medium: podping.MediumMusic,
+		Reason: podping.ReasonLive,
+		IRIs:   []string{"http://example.com/1.rss", "http://example.com/2.rss"},
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{
+		"medium=music&reason=live&url=http%3A%2F%2Fexample.com%2F1.rss",
+		"medium=music&reason=live&url=http%3A%2F%2Fexample.com%2F2.rss",
+	}, got)
+}
+
+func TestHTTPTransportSendError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+	tr := &podping.HTTPTransport{Endpoint: s.URL, Client: s.Client()}
+
+	// act
+	err := tr.Send(&podping.Notification{IRIs: []string{"http://example.com/1.rss"}})
+
+	// assert
+	assert.EqualError(t, err,
+		"podping.HTTPTransport.Send: http://example.com/1.rss: endpoint returned 401")
+}
@@ -0,0 +1,154 @@
+package podcast
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SkipHours represents the hours, from 0 to 23 in GMT, in which
+// aggregators may skip reading the feed.
+type SkipHours struct {
+	XMLName xml.Name `xml:"skipHours"`
+	Hours   []int    `xml:"hour"`
+}
+
+// SkipDays represents the days, such as Saturday, on which aggregators
+// may skip reading the feed.
+type SkipDays struct {
+	XMLName xml.Name `xml:"skipDays"`
+	Days    []string `xml:"day"`
+}
+
+// AddSkipHours adds the hours, from 0 to 23 in GMT, in which aggregators
+// may skip reading the feed.  Calling this method multiple times will
+// APPEND the hours, ignoring duplicates.
+//
+// An error is returned, and no hours are added, when any hour is out of
+// range.
+func (p *Podcast) AddSkipHours(hours ...int) error {
+	if err := validateSkipHours(hours); err != nil {
+		return err
+	}
+	if p.SkipHoursFormatted == nil {
+		p.SkipHoursFormatted = &SkipHours{}
+	}
+	for _, h := range hours {
+		if !containsHour(p.SkipHoursFormatted.Hours, h) {
+			p.SkipHoursFormatted.Hours = append(p.SkipHoursFormatted.Hours, h)
+		}
+	}
+	return nil
+}
+
+// AddSkipDays adds the days on which aggregators may skip reading the
+// feed.  Calling this method multiple times will APPEND the days,
+// ignoring duplicates.
+//
+// An error is returned, and no days are added, when any day is not a
+// valid time.Weekday.
+func (p *Podcast) AddSkipDays(days ...time.Weekday) error {
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			return errors.New("SkipDays.Day " + strconv.Itoa(int(d)) +
+				" is not a valid weekday")
+		}
+	}
+	if p.SkipDaysFormatted == nil {
+		p.SkipDaysFormatted = &SkipDays{}
+	}
+	for _, d := range days {
+		if !containsDay(p.SkipDaysFormatted.Days, d.String()) {
+			p.SkipDaysFormatted.Days = append(p.SkipDaysFormatted.Days, d.String())
+		}
+	}
+	return nil
+}
+
+func validateSkipHours(hours []int) error {
+	for _, h := range hours {
+		if h < 0 || h > 23 {
+			return errors.New("SkipHours.Hour " + strconv.Itoa(h) +
+				" must be from 0 to 23")
+		}
+	}
+	return nil
+}
+
+func validateSkipDays(days []string) error {
+	for _, d := range days {
+		if _, ok := parseWeekday(d); !ok {
+			return errors.New("SkipDays.Day " + d + " is not a day such as Monday")
+		}
+	}
+	return nil
+}
+
+// parseSkipHours returns the hours of the legacy SkipHours string, such
+// as "0,1,2" or "0 1 2", dropping any that are not valid hours.
+func parseSkipHours(s string) *SkipHours {
+	var hours []int
+	for _, f := range splitList(s) {
+		if h, err := strconv.Atoi(f); err == nil && validateSkipHours([]int{h}) == nil &&
+			!containsHour(hours, h) {
+			hours = append(hours, h)
+		}
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	return &SkipHours{Hours: hours}
+}
+
+// parseSkipDays returns the days of the legacy SkipDays string, such as
+// "Saturday,Sunday", dropping any that are not days.
+func parseSkipDays(s string) *SkipDays {
+	var days []string
+	for _, f := range splitList(s) {
+		if d, ok := parseWeekday(f); ok && !containsDay(days, d.String()) {
+			days = append(days, d.String())
+		}
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	return &SkipDays{Days: days}
+}
+
+// parseWeekday returns the time.Weekday named by s, ignoring case.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// splitList splits s on commas and white space.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+func containsHour(hours []int, h int) bool {
+	for _, v := range hours {
+		if v == h {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDay(days []string, d string) bool {
+	for _, v := range days {
+		if v == d {
+			return true
+		}
+	}
+	return false
+}
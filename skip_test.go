@@ -0,0 +1,133 @@
+package podcast_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSkipHoursInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddSkipHours(0, 24)
+
+	// assert
+	assert.EqualError(t, err, "SkipHours.Hour 24 must be from 0 to 23")
+	assert.Nil(t, p.SkipHoursFormatted)
+}
+
+func TestAddSkipHoursDuplicates(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	assert.NoError(t, p.AddSkipHours(0, 1))
+	err := p.AddSkipHours(1, 2)
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, []int{0, 1, 2}, p.SkipHoursFormatted.Hours)
+}
+
+func TestAddSkipDaysInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	err := p.AddSkipDays(time.Monday, time.Weekday(7))
+
+	// assert
+	assert.EqualError(t, err, "SkipDays.Day 7 is not a valid weekday")
+	assert.Nil(t, p.SkipDaysFormatted)
+}
+
+func TestAddCloudInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		domain, path, protocol string
+		port                   int
+		err                    string
+	}{
+		{"", "/RPC2", "xml-rpc", 80, "Cloud.Domain and Cloud.Path are required"},
+		{"rpc.example.com", "", "xml-rpc", 80, "Cloud.Domain and Cloud.Path are required"},
+		{"rpc.example.com", "/RPC2", "xml-rpc", 0, "Cloud.Port 0 must be from 1 to 65535"},
+		{"rpc.example.com", "/RPC2", "gopher", 80, "Cloud.Protocol gopher must be xml-rpc, soap or http-post"},
+	}
+	for _, tt := range tests {
+		// arrange
+		p := podcast.New("title", "link", "description", nil, nil)
+
+		// act
+		err := p.AddCloud(tt.domain, tt.port, tt.path, "notify", tt.protocol)
+
+		// assert
+		assert.EqualError(t, err, tt.err)
+		assert.Nil(t, p.CloudFormatted)
+	}
+}
+
+func TestEncodeLegacySkipAndCloud(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Cloud = "https://rpc.example.com:8443/notify"
+	p.SkipHours = "0, 1 25 x 1"
+	p.SkipDays = "saturday,Sunday,Someday"
+
+	// act
+	out := p.String()
+
+	// assert
+	assert.Contains(t, out, `<cloud domain="rpc.example.com" port="8443" path="/notify" registerProcedure="" protocol="http-post"></cloud>`)
+	assert.Contains(t, out, "<skipHours>\n      <hour>0</hour>\n      <hour>1</hour>\n    </skipHours>")
+	assert.Contains(t, out, "<skipDays>\n      <day>Saturday</day>\n      <day>Sunday</day>\n    </skipDays>")
+	assert.Nil(t, p.SkipHoursFormatted)
+}
+
+func TestEncodeLegacyInvalidCloud(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Cloud = "not a url"
+
+	// act
+	out := p.String()
+
+	// assert
+	assert.NotContains(t, out, "cloud")
+}
+
+func TestDecodeSkipAndCloud(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	assert.NoError(t, p.AddCloud("rpc.example.com", 80, "/RPC2", "pingMe", "soap"))
+	assert.NoError(t, p.AddSkipHours(22, 23))
+	assert.NoError(t, p.AddSkipDays(time.Sunday))
+
+	// act
+	d, err := podcast.Decode(strings.NewReader(p.String()))
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, p.CloudFormatted.Domain, d.CloudFormatted.Domain)
+	assert.EqualValues(t, 80, d.CloudFormatted.Port)
+	assert.EqualValues(t, "pingMe", d.CloudFormatted.RegisterProcedure)
+	assert.EqualValues(t, []int{22, 23}, d.SkipHoursFormatted.Hours)
+	assert.EqualValues(t, []string{"Sunday"}, d.SkipDaysFormatted.Days)
+}
@@ -0,0 +1,331 @@
+package podcast
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://github.com/Podcastindex-org/podcast-namespace/blob/main/transcripts/transcripts.md
+//
+
+// The transcript types supported by DecodeTranscript and Transcript.Encode,
+// which are also the MIME types of the podcast:transcript tag.
+const (
+	TranscriptSRT  = "application/x-subrip"
+	TranscriptVTT  = "text/vtt"
+	TranscriptJSON = "application/json"
+	TranscriptHTML = "text/html"
+)
+
+const transcriptJSONVersion = "1.0.0"
+
+// Transcript is the list of timed cues of an episode's transcript or
+// closed captions.
+type Transcript struct {
+	Cues []*Cue
+}
+
+// Cue is a line of the Transcript spoken from Start to End, with the
+// Speaker being optional.
+type Cue struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string
+	Text    string
+}
+
+type transcriptJSON struct {
+	Version  string                   `json:"version"`
+	Segments []*transcriptJSONSegment `json:"segments"`
+}
+
+type transcriptJSONSegment struct {
+	Speaker   string  `json:"speaker,omitempty"`
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime"`
+	Body      string  `json:"body"`
+}
+
+// DecodeTranscript reads a transcript of the transcriptType, one of
+// TranscriptSRT, TranscriptVTT, TranscriptJSON or TranscriptHTML.
+//
+// Decoding one type and encoding it as another converts between the
+// transcript formats.  Note that SRT has no speakers and HTML has no end
+// times, which are lost when converting from them.  SRT has no escaping
+// either, so its text is read as is, while the WebVTT markup is removed.
+func DecodeTranscript(r io.Reader, transcriptType string) (*Transcript, error) {
+	switch transcriptType {
+	case TranscriptSRT:
+		return decodeCues(r, false)
+	case TranscriptVTT:
+		return decodeCues(r, true)
+	case TranscriptJSON:
+		return decodeTranscriptJSON(r)
+	case TranscriptHTML:
+		return decodeTranscriptHTML(r)
+	}
+	return nil, errors.New("podcast.DecodeTranscript: " +
+		transcriptType + " is not a supported transcript type")
+}
+
+// Encode writes the Transcript to the io.Writer stream as the
+// transcriptType, one of TranscriptSRT, TranscriptVTT, TranscriptJSON or
+// TranscriptHTML.
+func (t *Transcript) Encode(w io.Writer, transcriptType string) error {
+	var err error
+	switch transcriptType {
+	case TranscriptSRT:
+		err = t.encodeSRT(w)
+	case TranscriptVTT:
+		err = t.encodeVTT(w)
+	case TranscriptJSON:
+		err = t.encodeJSON(w)
+	case TranscriptHTML:
+		err = t.encodeHTML(w)
+	default:
+		return errors.New("podcast.Transcript.Encode: " +
+			transcriptType + " is not a supported transcript type")
+	}
+	if err != nil {
+		return errors.Wrap(err, "podcast.Transcript.Encode: w.Write returned error")
+	}
+	return nil
+}
+
+func (t *Transcript) encodeSRT(w io.Writer) error {
+	for n, c := range t.Cues {
+		if _, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n", n+1,
+			formatCueTime(c.Start, ","), formatCueTime(c.End, ","), c.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Transcript) encodeVTT(w io.Writer) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	for _, c := range t.Cues {
+		text := vttEscaper.Replace(c.Text)
+		if len(c.Speaker) > 0 {
+			text = "<v " + vttEscaper.Replace(c.Speaker) + ">" + text
+		}
+		if _, err := fmt.Fprintf(w, "%s --> %s\n%s\n\n",
+			formatCueTime(c.Start, "."), formatCueTime(c.End, "."), text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Transcript) encodeJSON(w io.Writer) error {
+	out := &transcriptJSON{
+		Version:  transcriptJSONVersion,
+		Segments: []*transcriptJSONSegment{},
+	}
+	for _, c := range t.Cues {
+		out.Segments = append(out.Segments, &transcriptJSONSegment{
+			Speaker:   c.Speaker,
+			StartTime: c.Start.Seconds(),
+			EndTime:   c.End.Seconds(),
+			Body:      c.Text,
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	return e.Encode(out)
+}
+
+func (t *Transcript) encodeHTML(w io.Writer) error {
+	for _, c := range t.Cues {
+		if len(c.Speaker) > 0 {
+			if _, err := fmt.Fprintf(w, "<cite>%s:</cite>\n",
+				html.EscapeString(c.Speaker)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "<time>%s</time>\n<p>%s</p>\n",
+			formatHTMLTime(c.Start), html.EscapeString(c.Text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vttEscaper escapes the text of WebVTT cues, which also keeps "-->" out
+// of them.
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// cueTiming matches the "start --> end" line of SRT and WebVTT cues,
+// ignoring any WebVTT cue settings after it.
+var cueTiming = regexp.MustCompile(`^(\S+)\s+-->\s+(\S+)`)
+
+// cueVoice matches the WebVTT voice span naming the speaker of a cue.
+var cueVoice = regexp.MustCompile(`^<v(?:\.[^ >]*)?\s+([^>]+)>`)
+
+// cueTag matches the WebVTT and SRT markup within the cue text.
+var cueTag = regexp.MustCompile(`</?[^>]*>`)
+
+// decodeCues reads the blocks of SRT and WebVTT, which only differ in the
+// header, the decimal separator and the markup of vtt, skipping any block
+// without a timing.
+func decodeCues(r io.Reader, vtt bool) (*Transcript, error) {
+	t := &Transcript{}
+	var c *Cue
+	var lines []string
+	flush := func() {
+		if c != nil {
+			c.Text = strings.Join(lines, "\n")
+			t.Cues = append(t.Cues, c)
+		}
+		c, lines = nil, nil
+	}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(s.Text(), "\ufeff"))
+		switch {
+		case len(line) == 0:
+			flush()
+		case c == nil:
+			m := cueTiming.FindStringSubmatch(line)
+			if m == nil {
+				continue // header, cue identifier, NOTE or STYLE
+			}
+			start, err := parseCueTime(m[1])
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseCueTime(m[2])
+			if err != nil {
+				return nil, err
+			}
+			c = &Cue{Start: start, End: end}
+		case !vtt:
+			lines = append(lines, line)
+		default:
+			if v := cueVoice.FindStringSubmatch(line); v != nil && len(c.Speaker) == 0 {
+				c.Speaker = html.UnescapeString(strings.TrimSpace(v[1]))
+			}
+			lines = append(lines, html.UnescapeString(cueTag.ReplaceAllString(line, "")))
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "podcast.DecodeTranscript: s.Scan returned error")
+	}
+	flush()
+	return t, nil
+}
+
+func decodeTranscriptJSON(r io.Reader) (*Transcript, error) {
+	var in transcriptJSON
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, errors.Wrap(err, "podcast.DecodeTranscript: d.Decode returned error")
+	}
+	t := &Transcript{}
+	for _, s := range in.Segments {
+		t.Cues = append(t.Cues, &Cue{
+			Start:   secondsDuration(s.StartTime),
+			End:     secondsDuration(s.EndTime),
+			Speaker: s.Speaker,
+			Text:    s.Body,
+		})
+	}
+	return t, nil
+}
+
+// decodeTranscriptHTML reads the cite, time and p elements of an HTML
+// transcript, where the speaker carries over to the following paragraphs.
+func decodeTranscriptHTML(r io.Reader) (*Transcript, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	t := &Transcript{}
+	var speaker string
+	var start time.Duration
+	var text []string
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "podcast.DecodeTranscript: d.Token returned error")
+		}
+		switch tt := tok.(type) {
+		case xml.StartElement:
+			switch strings.ToLower(tt.Name.Local) {
+			case "cite", "time", "p":
+				text = nil
+			}
+		case xml.CharData:
+			text = append(text, string(tt))
+		case xml.EndElement:
+			s := strings.TrimSpace(strings.Join(text, ""))
+			switch strings.ToLower(tt.Name.Local) {
+			case "cite":
+				speaker = strings.TrimSuffix(s, ":")
+			case "time":
+				start = time.Duration(parseDurationSeconds(s)) * time.Second
+			case "p":
+				if len(s) > 0 {
+					t.Cues = append(t.Cues, &Cue{Start: start, Speaker: speaker, Text: s})
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+// parseCueTime parses the "HH:MM:SS,mmm" and "MM:SS.mmm" timestamps of
+// SRT and WebVTT cues.
+var parseCueTime = func(s string) (time.Duration, error) {
+	parts := strings.Split(strings.Replace(s, ",", ".", 1), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.New("podcast.DecodeTranscript: " + s + " is not a valid timestamp")
+	}
+	var minutes int64
+	for _, part := range parts[:len(parts)-1] {
+		v, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || v < 0 {
+			return 0, errors.New("podcast.DecodeTranscript: " + s + " is not a valid timestamp")
+		}
+		minutes = minutes*60 + v
+	}
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 {
+		return 0, errors.New("podcast.DecodeTranscript: " + s + " is not a valid timestamp")
+	}
+	return time.Duration(minutes)*time.Minute + secondsDuration(seconds), nil
+}
+
+func formatCueTime(d time.Duration, sep string) string {
+	ms := d.Nanoseconds() / int64(time.Millisecond)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+func formatHTMLTime(d time.Duration) string {
+	s := int64(d.Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s*1000+0.5) * time.Millisecond
+}
@@ -0,0 +1,158 @@
+package podcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeTranscriptVTT(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	vtt := "WEBVTT\n\nNOTE a comment\n\nintro\n01:02.500 --> 1:01:03.000 align:start\n" +
+		"<v.loud Jane Doe>Hello <b>there</b> &amp; welcome</v>\nsecond line\n"
+
+	// act
+	tr, err := podcast.DecodeTranscript(strings.NewReader(vtt), podcast.TranscriptVTT)
+
+	// assert
+	if assert.NoError(t, err) && assert.Len(t, tr.Cues, 1) {
+		c := tr.Cues[0]
+		assert.EqualValues(t, 62500*time.Millisecond, c.Start)
+		assert.EqualValues(t, time.Hour+time.Minute+3*time.Second, c.End)
+		assert.EqualValues(t, "Jane Doe", c.Speaker)
+		assert.EqualValues(t, "Hello there & welcome\nsecond line", c.Text)
+	}
+}
+
+func TestDecodeTranscriptHTML(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	doc := `<html><body><cite>Jane:</cite><time>0:05</time><p>Hi <b>all</b></p>` +
+		`<time>1:00:10</time><p>Still Jane&nbsp;here</p></body></html>`
+
+	// act
+	tr, err := podcast.DecodeTranscript(strings.NewReader(doc), podcast.TranscriptHTML)
+
+	// assert
+	if assert.NoError(t, err) && assert.Len(t, tr.Cues, 2) {
+		assert.EqualValues(t, "Jane", tr.Cues[0].Speaker)
+		assert.EqualValues(t, 5*time.Second, tr.Cues[0].Start)
+		assert.EqualValues(t, "Hi all", tr.Cues[0].Text)
+		assert.EqualValues(t, "Jane", tr.Cues[1].Speaker)
+		assert.EqualValues(t, time.Hour+10*time.Second, tr.Cues[1].Start)
+	}
+}
+
+func TestTranscriptRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	in := &podcast.Transcript{Cues: []*podcast.Cue{
+		{Start: 1250 * time.Millisecond, End: 2 * time.Hour, Speaker: "Jane", Text: "one"},
+	}}
+
+	for _, tt := range []string{podcast.TranscriptVTT, podcast.TranscriptJSON} {
+		var b bytes.Buffer
+
+		// act
+		err := in.Encode(&b, tt)
+		out, derr := podcast.DecodeTranscript(&b, tt)
+
+		// assert
+		assert.NoError(t, err, tt)
+		if assert.NoError(t, derr, tt) {
+			assert.EqualValues(t, in, out, tt)
+		}
+	}
+}
+
+func TestTranscriptSRTToVTT(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	srt := "1\n00:00:01,000 --> 00:00:02,500\nA <b> x < y & z\nthen --> now\n\n"
+	in, err := podcast.DecodeTranscript(strings.NewReader(srt), podcast.TranscriptSRT)
+	assert.NoError(t, err)
+	in.Cues[0].Speaker = "<Jane & Joe>"
+	var vtt, out bytes.Buffer
+
+	// act
+	err = in.Encode(&vtt, podcast.TranscriptVTT)
+	tr, derr := podcast.DecodeTranscript(bytes.NewReader(vtt.Bytes()), podcast.TranscriptVTT)
+	serr := tr.Encode(&out, podcast.TranscriptSRT)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, derr)
+	assert.NoError(t, serr)
+	assert.EqualValues(t, "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\n"+
+		"<v &lt;Jane &amp; Joe&gt;>A &lt;b&gt; x &lt; y &amp; z\nthen --&gt; now\n\n", vtt.String())
+	assert.EqualValues(t, in, tr)
+	assert.EqualValues(t, srt, out.String())
+}
+
+func TestDecodeTranscriptInvalid(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tests := []struct {
+		name, typ, in string
+	}{
+		{"type", "text/plain", "hello"},
+		{"timestamp", podcast.TranscriptSRT, "1\n00:00:xx,000 --> 00:00:01,000\nhi\n"},
+		{"json", podcast.TranscriptJSON, "{"},
+	}
+	for _, tt := range tests {
+		// act
+		tr, err := podcast.DecodeTranscript(strings.NewReader(tt.in), tt.typ)
+
+		// assert
+		assert.Nil(t, tr, tt.name)
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func TestTranscriptEncodeError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	tr := &podcast.Transcript{Cues: []*podcast.Cue{{Text: "one"}}}
+
+	for _, tt := range []string{podcast.TranscriptSRT, podcast.TranscriptVTT,
+		podcast.TranscriptJSON, podcast.TranscriptHTML, "text/plain"} {
+		// act
+		err := tr.Encode(&errWriter{}, tt)
+
+		// assert
+		assert.Error(t, err, tt)
+	}
+}
+
+func TestAddTranscriptClosedCaptioned(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	video := podcast.Item{Title: "video", Description: "desc"}
+	video.AddEnclosure("http://a.co/1.mp4", podcast.MP4, 1)
+	late := podcast.Item{Title: "late", Description: "desc"}
+	audio := podcast.Item{Title: "audio", Description: "desc"}
+	audio.AddEnclosure("http://a.co/1.mp3", podcast.MP3, 1)
+
+	// act
+	_ = video.AddTranscript("http://a.co/1.vtt", podcast.TranscriptVTT, "en", "captions")
+	_ = late.AddTranscript("http://a.co/2.vtt", podcast.TranscriptVTT, "en", "captions")
+	late.AddEnclosure("http://a.co/2.m4v", podcast.M4V, 1)
+	_ = audio.AddTranscript("http://a.co/3.vtt", podcast.TranscriptVTT, "en", "captions")
+
+	// assert
+	assert.EqualValues(t, "Yes", video.IIsClosedCaptioned)
+	assert.EqualValues(t, "Yes", late.IIsClosedCaptioned)
+	assert.EqualValues(t, "", audio.IIsClosedCaptioned)
+}
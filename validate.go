@@ -0,0 +1,560 @@
+package podcast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Severity specifies how serious a validation Finding is.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// Severity specifies how serious a validation Finding is.
+type Severity int
+
+// String returns the lowercase name of the Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Profile specifies a set of rules for Podcast.Validate to check against.
+// The RSS 2.0 rules are always included with the other profiles.
+const (
+	ProfileRSS Profile = iota
+	ProfileApple
+	ProfileSpotify
+	ProfilePodcasting20
+)
+
+// Profile specifies a set of rules for Podcast.Validate to check against.
+type Profile int
+
+// String returns the name of the Profile.
+func (p Profile) String() string {
+	switch p {
+	case ProfileRSS:
+		return "rss"
+	case ProfileApple:
+		return "apple"
+	case ProfileSpotify:
+		return "spotify"
+	case ProfilePodcasting20:
+		return "podcasting2.0"
+	}
+	return "unknown"
+}
+
+// Finding is a single problem found by Podcast.Validate.
+type Finding struct {
+	// Severity is how serious the problem is.
+	Severity Severity
+	// Path is the Go field path of the problem, such as
+	// "Items[3].Enclosure.Length".  It is empty for the Podcast itself.
+	Path string
+	// Rule is the identifier of the rule that was broken, such as
+	// "apple-channel-image".
+	Rule string
+	// Message describes the problem.
+	Message string
+}
+
+// String formats the Finding as "severity path: message (rule)".
+func (f Finding) String() string {
+	path := f.Path
+	if len(path) == 0 {
+		path = "Podcast"
+	}
+	return fmt.Sprintf("%s %s: %s (%s)", f.Severity, path, f.Message, f.Rule)
+}
+
+// Report is the result of Podcast.Validate.
+type Report struct {
+	Profiles []Profile
+	Findings []Finding
+}
+
+// HasErrors returns true if any Finding is of SeverityError.
+func (r *Report) HasErrors() bool {
+	return len(r.Filter(SeverityError)) > 0
+}
+
+// Filter returns the Findings with a Severity of at least min.
+func (r *Report) Filter(min Severity) []Finding {
+	var found []Finding
+	for _, f := range r.Findings {
+		if f.Severity >= min {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// Err returns an error listing all Findings of SeverityError, or nil if
+// there are none.
+func (r *Report) Err() error {
+	errs := r.Filter(SeverityError)
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, f := range errs {
+		msgs = append(msgs, f.String())
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// Validate checks the Podcast and every Item against the rules of the
+// specified profiles and returns a Report of all findings.  The RSS 2.0
+// rules, including the Item requirements enforced by AddItem, are always
+// checked, except for the iTunes episode numbering checked by ProfileApple.
+//
+// Unlike AddItem, Validate does not stop at the first problem and does not
+// modify the Podcast, which makes it suitable for checking decoded feeds
+// before publishing.
+func (p *Podcast) Validate(profiles ...Profile) *Report {
+	v := &validator{}
+	r := &Report{Profiles: []Profile{ProfileRSS}}
+	for _, profile := range profiles {
+		if !containsProfile(r.Profiles, profile) {
+			r.Profiles = append(r.Profiles, profile)
+		}
+	}
+	for _, profile := range r.Profiles {
+		for _, check := range profileRules[profile] {
+			check(v, p)
+		}
+	}
+	r.Findings = v.findings
+	return r
+}
+
+// validator collects the Findings of the rules.
+type validator struct {
+	findings []Finding
+}
+
+func (v *validator) add(s Severity, path, rule, msg string) {
+	v.findings = append(v.findings, Finding{
+		Severity: s,
+		Path:     path,
+		Rule:     rule,
+		Message:  msg,
+	})
+}
+
+// rule checks one aspect of a Podcast and adds any Findings to v.
+type rule func(v *validator, p *Podcast)
+
+var profileRules = map[Profile][]rule{
+	ProfileRSS: {
+		ruleRSSChannel,
+		ruleRSSLanguage,
+		ruleRSSItems,
+		ruleRSSItemGUIDs,
+	},
+	ProfileApple: {
+		ruleAppleChannel,
+		ruleAppleExplicit,
+		ruleAppleCategories,
+		ruleAppleItems,
+	},
+	ProfileSpotify: {
+		ruleSpotifyChannel,
+		ruleSpotifyItems,
+	},
+	ProfilePodcasting20: {
+		rulePodcastingChannel,
+		rulePodcastingItems,
+	},
+}
+
+// itemError is a failed Item requirement, shared by AddItem and Validate.
+type itemError struct {
+	rule  string
+	field string
+	msg   string
+}
+
+func (e *itemError) Error() string {
+	return e.msg
+}
+
+// itemErrors returns every failed requirement of the Item that AddItem
+// enforces, in the order AddItem checks them, or only those reported by
+// the rules of the profiles when any are given.
+func (p *Podcast) itemErrors(i *Item, profiles ...Profile) []*itemError {
+	var errs []*itemError
+	for _, c := range itemChecks {
+		if len(profiles) > 0 && !containsProfile(profiles, c.profile) {
+			continue
+		}
+		if err := c.check(p, i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// itemChecks are the requirements of AddItem, along with the Profile
+// whose rules report them in Validate.
+var itemChecks = []struct {
+	profile Profile
+	check   func(p *Podcast, i *Item) *itemError
+}{
+	{ProfileRSS, checkItemTitle},
+	{ProfileRSS, checkItemEnclosure},
+	{ProfileRSS, checkItemPubDate},
+	{ProfileRSS, checkItemGUID},
+	{ProfileApple, checkItemITunes},
+	{ProfileRSS, checkItemPodcasting},
+}
+
+func checkItemTitle(p *Podcast, i *Item) *itemError {
+	if len(i.Title) == 0 || len(i.Description) == 0 {
+		return &itemError{"rss-item-title", "Title",
+			"Title and Description are required"}
+	}
+	return nil
+}
+
+func checkItemEnclosure(p *Podcast, i *Item) *itemError {
+	switch {
+	case i.Enclosure == nil && len(i.Link) == 0:
+		return &itemError{"rss-item-link", "Link",
+			i.Title + ": Link is required when not using Enclosure"}
+	case i.Enclosure == nil:
+		return nil
+	case len(i.Enclosure.URL) == 0:
+		return &itemError{"rss-item-enclosure-url", "Enclosure.URL",
+			i.Title + ": Enclosure.URL is required"}
+	case i.Enclosure.Type.String() == enclosureDefault:
+		return &itemError{"rss-item-enclosure-type", "Enclosure.Type",
+			i.Title + ": Enclosure.Type is required"}
+	}
+	return nil
+}
+
+func checkItemPubDate(p *Podcast, i *Item) *itemError {
+	if p.StrictDates && (i.PubDate == nil || i.PubDate.IsZero()) {
+		return &itemError{"rss-item-pubdate-required", "PubDate",
+			i.Title + ": PubDate is required"}
+	}
+	return nil
+}
+
+func checkItemGUID(p *Podcast, i *Item) *itemError {
+	if p.StrictGUIDs && len(i.GUID) == 0 &&
+		(i.GUIDFormatted == nil || len(i.GUIDFormatted.Value) == 0) {
+		return &itemError{"rss-item-guid-required", "GUID",
+			i.Title + ": GUID is required instead of being derived from URLs"}
+	}
+	return nil
+}
+
+func checkItemITunes(p *Podcast, i *Item) *itemError {
+	if err := p.validateItemITunes(i); err != nil {
+		return &itemError{"apple-item-episode", "IEpisode", err.Error()}
+	}
+	return nil
+}
+
+func checkItemPodcasting(p *Podcast, i *Item) *itemError {
+	for n, t := range i.PTranscripts {
+		if err := validateTranscript(t); err != nil {
+			return &itemError{"podcasting-item-transcript",
+				fmt.Sprintf("PTranscripts[%d]", n), i.Title + ": " + err.Error()}
+		}
+	}
+	if i.PChapters != nil {
+		if err := validateChapters(i.PChapters); err != nil {
+			return &itemError{"podcasting-item-chapters", "PChapters",
+				i.Title + ": " + err.Error()}
+		}
+	}
+	if i.PValue != nil {
+		if err := validateValue(i.PValue); err != nil {
+			return &itemError{"podcasting-item-value", "PValue",
+				i.Title + ": " + err.Error()}
+		}
+	}
+	return nil
+}
+
+func itemPath(n int, field string) string {
+	path := fmt.Sprintf("Items[%d]", n)
+	if len(field) > 0 {
+		path += "." + field
+	}
+	return path
+}
+
+func ruleRSSChannel(v *validator, p *Podcast) {
+	if len(p.Title) == 0 {
+		v.add(SeverityError, "Title", "rss-channel-title", "Title is required")
+	}
+	if len(p.Link) == 0 {
+		v.add(SeverityError, "Link", "rss-channel-link", "Link is required")
+	}
+	if len(p.Description) == 0 {
+		v.add(SeverityError, "Description", "rss-channel-description",
+			"Description is required")
+	}
+	if p.TTL < 0 {
+		v.add(SeverityError, "TTL", "rss-channel-ttl", "TTL must not be negative")
+	}
+	if p.CloudFormatted != nil {
+		if err := validateCloud(p.CloudFormatted); err != nil {
+			v.add(SeverityError, "CloudFormatted", "rss-channel-cloud", err.Error())
+		}
+	}
+	if p.SkipHoursFormatted != nil {
+		if err := validateSkipHours(p.SkipHoursFormatted.Hours); err != nil {
+			v.add(SeverityError, "SkipHoursFormatted", "rss-channel-skiphours", err.Error())
+		}
+	}
+	if p.SkipDaysFormatted != nil {
+		if err := validateSkipDays(p.SkipDaysFormatted.Days); err != nil {
+			v.add(SeverityError, "SkipDaysFormatted", "rss-channel-skipdays", err.Error())
+		}
+	}
+	if len(p.Hubs()) > 0 && p.AtomLink == nil {
+		v.add(SeverityWarning, "AtomLink", "rss-channel-hub-self",
+			"AtomLink is required for WebSub hubs to identify the feed")
+	}
+}
+
+var languageCode = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+func ruleRSSLanguage(v *validator, p *Podcast) {
+	if len(p.Language) > 0 && !languageCode.MatchString(p.Language) {
+		v.add(SeverityWarning, "Language", "rss-channel-language",
+			p.Language+" is not a valid language code such as en-us")
+	}
+}
+
+func ruleRSSItems(v *validator, p *Podcast) {
+	for n, i := range p.Items {
+		for _, err := range p.itemErrors(i, ProfileRSS) {
+			v.add(SeverityError, itemPath(n, err.field), err.rule, err.msg)
+		}
+		if len(i.PubDateFormatted) > 0 && parseDate(i.PubDateFormatted) == nil {
+			v.add(SeverityWarning, itemPath(n, "PubDateFormatted"),
+				"rss-item-pubdate", i.PubDateFormatted+" is not an RFC 1123 date")
+		}
+	}
+}
+
+func ruleRSSItemGUIDs(v *validator, p *Podcast) {
+	seen := map[string]int{}
+	for n, i := range p.Items {
+		if len(i.GUID) == 0 {
+			if !p.StrictGUIDs {
+				v.add(SeverityWarning, itemPath(n, "GUID"), "rss-item-guid",
+					"GUID is recommended so apps can track the episode")
+			}
+			continue
+		}
+		if g := i.GUIDFormatted; g != nil && g.IsPermaLink != "false" && !isPermaLink(g.Value) {
+			v.add(SeverityWarning, itemPath(n, "GUIDFormatted.IsPermaLink"),
+				"rss-item-guid-permalink",
+				"GUID "+g.Value+" is not a URL and should have isPermaLink=\"false\"")
+		}
+		if first, ok := seen[i.GUID]; ok {
+			v.add(SeverityError, itemPath(n, "GUID"), "rss-item-guid-unique",
+				fmt.Sprintf("GUID %s duplicates Items[%d]", i.GUID, first))
+			continue
+		}
+		seen[i.GUID] = n
+	}
+}
+
+func ruleAppleChannel(v *validator, p *Podcast) {
+	if p.IImage == nil || len(p.IImage.HREF) == 0 {
+		v.add(SeverityError, "IImage", "apple-channel-image", "IImage is required")
+	} else if !hasImageExtension(p.IImage.HREF) {
+		v.add(SeverityWarning, "IImage.HREF", "apple-channel-image-type",
+			"IImage should be a .jpg or .png file")
+	}
+	if len(p.Language) == 0 {
+		v.add(SeverityError, "Language", "apple-channel-language",
+			"Language is required")
+	}
+	if p.IOwner == nil || len(p.IOwner.Email) == 0 {
+		v.add(SeverityWarning, "IOwner.Email", "apple-channel-owner",
+			"IOwner.Email is used by Apple to verify ownership")
+	}
+	if len(p.IAuthor) == 0 {
+		v.add(SeverityWarning, "IAuthor", "apple-channel-author",
+			"IAuthor is recommended")
+	}
+}
+
+func ruleAppleExplicit(v *validator, p *Podcast) {
+	if !validExplicit(p.IExplicit) || len(p.IExplicit) == 0 {
+		v.add(SeverityError, "IExplicit", "apple-channel-explicit",
+			"IExplicit must be true or false, or the legacy yes, no or clean")
+	}
+	for n, i := range p.Items {
+		if !validExplicit(i.IExplicit) {
+			v.add(SeverityError, itemPath(n, "IExplicit"), "apple-item-explicit",
+				"IExplicit must be true or false, or the legacy yes, no or clean")
+		}
+	}
+}
+
+func ruleAppleCategories(v *validator, p *Podcast) {
+	if len(p.ICategories) == 0 {
+		v.add(SeverityError, "ICategories", "apple-channel-category",
+			"at least one ICategory is required")
+	}
+	for n, c := range p.ICategories {
+		var subs []string
+		for _, s := range c.ICategories {
+			subs = append(subs, s.Text)
+		}
+		if err := ValidateAppleCategory(c.Text, subs); err != nil {
+			v.add(SeverityError, fmt.Sprintf("ICategories[%d]", n),
+				"apple-channel-category", err.Error())
+		}
+	}
+}
+
+func ruleAppleItems(v *validator, p *Podcast) {
+	for n, i := range p.Items {
+		for _, err := range p.itemErrors(i, ProfileApple) {
+			v.add(SeverityError, itemPath(n, err.field), err.rule, err.msg)
+		}
+		if i.Enclosure == nil {
+			v.add(SeverityError, itemPath(n, "Enclosure"), "apple-item-enclosure",
+				"Enclosure is required")
+			continue
+		}
+		if i.Enclosure.Length <= 0 {
+			v.add(SeverityWarning, itemPath(n, "Enclosure.Length"),
+				"apple-item-enclosure-length", "Enclosure.Length should be set")
+		}
+		if len(i.IDuration) == 0 {
+			v.add(SeverityWarning, itemPath(n, "IDuration"), "apple-item-duration",
+				"IDuration is recommended")
+		}
+	}
+}
+
+func ruleSpotifyChannel(v *validator, p *Podcast) {
+	if p.Image == nil && p.IImage == nil {
+		v.add(SeverityError, "IImage", "spotify-channel-image",
+			"IImage or Image is required")
+	}
+	if len(p.Language) == 0 {
+		v.add(SeverityError, "Language", "spotify-channel-language",
+			"Language is required")
+	}
+	if len(p.IAuthor) == 0 && len(p.ManagingEditor) == 0 {
+		v.add(SeverityWarning, "IAuthor", "spotify-channel-author",
+			"IAuthor or ManagingEditor is recommended")
+	}
+	if utf8.RuneCountInString(p.Description) > 4000 {
+		v.add(SeverityError, "Description", "spotify-channel-description",
+			"Description is limited to 4000 characters")
+	}
+}
+
+func ruleSpotifyItems(v *validator, p *Podcast) {
+	for n, i := range p.Items {
+		if i.Enclosure == nil {
+			v.add(SeverityError, itemPath(n, "Enclosure"), "spotify-item-enclosure",
+				"Enclosure is required")
+			continue
+		}
+		if !strings.HasPrefix(i.Enclosure.Type.String(), "audio/") {
+			v.add(SeverityWarning, itemPath(n, "Enclosure.Type"),
+				"spotify-item-enclosure-type", "Enclosure.Type should be audio")
+		}
+		if i.Enclosure.Length <= 0 {
+			v.add(SeverityError, itemPath(n, "Enclosure.Length"),
+				"spotify-item-enclosure-length", "Enclosure.Length is required")
+		}
+	}
+}
+
+func rulePodcastingChannel(v *validator, p *Podcast) {
+	if p.PLocked == nil {
+		v.add(SeverityWarning, "PLocked", "podcasting-channel-locked",
+			"PLocked is recommended to protect the feed from being imported")
+	} else if len(p.PLocked.Owner) == 0 {
+		v.add(SeverityError, "PLocked.Owner", "podcasting-channel-locked",
+			"PLocked.Owner is required")
+	}
+	for n, f := range p.PFunding {
+		if len(f.URL) == 0 {
+			v.add(SeverityError, fmt.Sprintf("PFunding[%d].URL", n),
+				"podcasting-channel-funding", "PFunding.URL is required")
+		}
+	}
+	if p.PValue != nil {
+		if err := validateValue(p.PValue); err != nil {
+			v.add(SeverityError, "PValue", "podcasting-channel-value", err.Error())
+		}
+	}
+	if len(p.PGUID) > 0 && !uuidPattern.MatchString(p.PGUID) {
+		v.add(SeverityError, "PGUID", "podcasting-channel-guid",
+			p.PGUID+" is not a UUID")
+	}
+}
+
+var uuidPattern = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func rulePodcastingItems(v *validator, p *Podcast) {
+	for n, i := range p.Items {
+		if len(i.PTranscripts) == 0 {
+			v.add(SeverityInfo, itemPath(n, "PTranscripts"),
+				"podcasting-item-transcript", "a transcript is recommended")
+		}
+	}
+}
+
+func containsProfile(profiles []Profile, profile Profile) bool {
+	for _, p := range profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
+
+func validExplicit(s string) bool {
+	switch s {
+	case "", "true", "false", "yes", "no", "clean":
+		return true
+	}
+	return false
+}
+
+func hasImageExtension(url string) bool {
+	u := strings.ToLower(url)
+	if n := strings.IndexAny(u, "?#"); n >= 0 {
+		u = u[:n]
+	}
+	for _, ext := range []string{".jpg", ".jpeg", ".png"} {
+		if strings.HasSuffix(u, ext) {
+			return true
+		}
+	}
+	return false
+}
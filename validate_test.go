@@ -0,0 +1,265 @@
+package podcast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+var severityTests = []struct {
+	s        podcast.Severity
+	expected string
+}{
+	{podcast.SeverityInfo, "info"},
+	{podcast.SeverityWarning, "warning"},
+	{podcast.SeverityError, "error"},
+	{99, "unknown"},
+}
+
+func TestSeverityString(t *testing.T) {
+	t.Parallel()
+	for _, st := range severityTests {
+		assert.EqualValues(t, st.expected, st.s.String())
+	}
+}
+
+var profileTests = []struct {
+	p        podcast.Profile
+	expected string
+}{
+	{podcast.ProfileRSS, "rss"},
+	{podcast.ProfileApple, "apple"},
+	{podcast.ProfileSpotify, "spotify"},
+	{podcast.ProfilePodcasting20, "podcasting2.0"},
+	{99, "unknown"},
+}
+
+func TestProfileString(t *testing.T) {
+	t.Parallel()
+	for _, pt := range profileTests {
+		assert.EqualValues(t, pt.expected, pt.p.String())
+	}
+}
+
+func hasFinding(r *podcast.Report, path, rule string) bool {
+	for _, f := range r.Findings {
+		if f.Path == path && f.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateEmptyPodcast(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.Podcast{TTL: -1, Language: "english please"}
+
+	// act
+	r := p.Validate()
+
+	// assert
+	assert.True(t, r.HasErrors())
+	assert.EqualValues(t, []podcast.Profile{podcast.ProfileRSS}, r.Profiles)
+	assert.True(t, hasFinding(r, "Title", "rss-channel-title"))
+	assert.True(t, hasFinding(r, "Link", "rss-channel-link"))
+	assert.True(t, hasFinding(r, "Description", "rss-channel-description"))
+	assert.True(t, hasFinding(r, "TTL", "rss-channel-ttl"))
+	assert.True(t, hasFinding(r, "Language", "rss-channel-language"))
+	assert.Error(t, r.Err())
+	assert.Contains(t, r.Err().Error(), "error Title: Title is required (rss-channel-title)")
+}
+
+func TestValidateItemsAllFindings(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Items = []*podcast.Item{
+		{PubDateFormatted: "yesterday"},
+		{Title: "t", Description: "d", Enclosure: &podcast.Enclosure{Type: 99}},
+		{Title: "t", Description: "d", Link: "l", GUID: "a", ISeason: -1},
+		{Title: "t", Description: "d", Link: "l", GUID: "a", IEpisodeType: "x"},
+		{Title: "t", Description: "d", Link: "l", PChapters: &podcast.PChapters{}},
+		{Title: "t", Description: "d", Link: "l", PTranscripts: []*podcast.PTranscript{{}}},
+	}
+
+	// act
+	r := p.Validate(podcast.ProfileRSS)
+
+	// assert
+	assert.EqualValues(t, []podcast.Profile{podcast.ProfileRSS}, r.Profiles)
+	assert.True(t, hasFinding(r, "Items[0].Title", "rss-item-title"))
+	assert.True(t, hasFinding(r, "Items[0].Link", "rss-item-link"))
+	assert.True(t, hasFinding(r, "Items[0].PubDateFormatted", "rss-item-pubdate"))
+	assert.True(t, hasFinding(r, "Items[0].GUID", "rss-item-guid"))
+	assert.True(t, hasFinding(r, "Items[1].Enclosure.URL", "rss-item-enclosure-url"))
+	assert.False(t, hasFinding(r, "Items[2].IEpisode", "apple-item-episode"))
+	assert.True(t, hasFinding(r, "Items[3].GUID", "rss-item-guid-unique"))
+	assert.True(t, hasFinding(r, "Items[4].PChapters", "podcasting-item-chapters"))
+	assert.True(t, hasFinding(r, "Items[5].PTranscripts[0]", "podcasting-item-transcript"))
+	apple := p.Validate(podcast.ProfileApple)
+	assert.True(t, hasFinding(apple, "Items[2].IEpisode", "apple-item-episode"))
+	assert.True(t, hasFinding(apple, "Items[3].IEpisode", "apple-item-episode"))
+}
+
+func TestValidateApple(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Language = ""
+	p.IExplicit = "maybe"
+	p.AddCategory("Games & Hobbies", nil)
+	p.Items = []*podcast.Item{
+		{Title: "t", Description: "d", Link: "l", GUID: "1", IExplicit: "perhaps"},
+	}
+
+	// act
+	r := p.Validate(podcast.ProfileApple)
+
+	// assert
+	assert.True(t, hasFinding(r, "IImage", "apple-channel-image"))
+	assert.True(t, hasFinding(r, "Language", "apple-channel-language"))
+	assert.True(t, hasFinding(r, "IOwner.Email", "apple-channel-owner"))
+	assert.True(t, hasFinding(r, "IAuthor", "apple-channel-author"))
+	assert.True(t, hasFinding(r, "IExplicit", "apple-channel-explicit"))
+	assert.True(t, hasFinding(r, "Items[0].IExplicit", "apple-item-explicit"))
+	assert.True(t, hasFinding(r, "ICategories[0]", "apple-channel-category"))
+	assert.True(t, hasFinding(r, "Items[0].Enclosure", "apple-item-enclosure"))
+}
+
+func TestValidateAppleMissingCategory(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	r := p.Validate(podcast.ProfileApple, podcast.ProfileApple)
+
+	// assert
+	assert.Len(t, r.Profiles, 2)
+	assert.True(t, hasFinding(r, "ICategories", "apple-channel-category"))
+}
+
+func TestValidateSpotify(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.Language = ""
+	for len(p.Description) <= 4000 {
+		p.Description += "description "
+	}
+	p.Items = []*podcast.Item{
+		{Title: "t", Description: "d", Link: "l", GUID: "1"},
+		{Title: "t", Description: "d", GUID: "2",
+			Enclosure: &podcast.Enclosure{URL: "u", Type: podcast.MP4}},
+	}
+
+	// act
+	r := p.Validate(podcast.ProfileSpotify)
+
+	// assert
+	assert.True(t, hasFinding(r, "IImage", "spotify-channel-image"))
+	assert.True(t, hasFinding(r, "Language", "spotify-channel-language"))
+	assert.True(t, hasFinding(r, "IAuthor", "spotify-channel-author"))
+	assert.True(t, hasFinding(r, "Description", "spotify-channel-description"))
+	assert.True(t, hasFinding(r, "Items[0].Enclosure", "spotify-item-enclosure"))
+	assert.True(t, hasFinding(r, "Items[1].Enclosure.Type", "spotify-item-enclosure-type"))
+	assert.True(t, hasFinding(r, "Items[1].Enclosure.Length", "spotify-item-enclosure-length"))
+}
+
+func TestValidateSpotifyDescriptionCharacters(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", strings.Repeat("é", 4000), nil, nil)
+
+	// act
+	r := p.Validate(podcast.ProfileSpotify)
+
+	// assert
+	assert.False(t, hasFinding(r, "Description", "spotify-channel-description"))
+}
+
+func TestValidateStrictPubDate(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil, podcast.WithStrictDates())
+	p.Items = []*podcast.Item{
+		{Title: "t", Description: "d", Link: "l", GUID: "1"},
+		{Title: "t", Description: "d", Link: "l", GUID: "2", PubDateFormatted: "yesterday"},
+	}
+
+	// act
+	r := p.Validate(podcast.ProfileRSS)
+
+	// assert
+	assert.True(t, hasFinding(r, "Items[0].PubDate", "rss-item-pubdate-required"))
+	assert.False(t, hasFinding(r, "Items[0].PubDate", "rss-item-pubdate"))
+	assert.True(t, hasFinding(r, "Items[1].PubDateFormatted", "rss-item-pubdate"))
+}
+
+func TestValidatePodcasting20(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.PFunding = []*podcast.PFunding{{}}
+	p.Items = []*podcast.Item{{Title: "t", Description: "d", Link: "l", GUID: "1"}}
+	p2 := podcast.New("title", "link", "description", nil, nil)
+	p2.PLocked = &podcast.PLocked{Text: "yes"}
+	p2.PValue = &podcast.PValue{Type: "lightning", Method: "keysend"}
+
+	// act
+	r := p.Validate(podcast.ProfilePodcasting20)
+	r2 := p2.Validate(podcast.ProfilePodcasting20)
+
+	// assert
+	assert.True(t, hasFinding(r, "PLocked", "podcasting-channel-locked"))
+	assert.True(t, hasFinding(r, "PFunding[0].URL", "podcasting-channel-funding"))
+	assert.True(t, hasFinding(r, "Items[0].PTranscripts", "podcasting-item-transcript"))
+	assert.True(t, hasFinding(r2, "PLocked.Owner", "podcasting-channel-locked"))
+	assert.True(t, hasFinding(r2, "PValue", "podcasting-channel-value"))
+}
+
+func TestReportNoErrors(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+
+	// act
+	r := p.Validate()
+
+	// assert
+	assert.False(t, r.HasErrors())
+	assert.NoError(t, r.Err())
+	assert.Len(t, r.Filter(podcast.SeverityInfo), len(r.Findings))
+}
+
+func TestValidateChannelElements(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "link", "description", nil, nil)
+	p.CloudFormatted = &podcast.Cloud{Domain: "rpc.example.com", Path: "/RPC2", Protocol: "xml-rpc"}
+	p.SkipHoursFormatted = &podcast.SkipHours{Hours: []int{1, 24}}
+	p.SkipDaysFormatted = &podcast.SkipDays{Days: []string{"Caturday"}}
+	p.AddHub("http://hub.example.com/")
+
+	// act
+	r := p.Validate()
+
+	// assert
+	assert.True(t, hasFinding(r, "CloudFormatted", "rss-channel-cloud"))
+	assert.True(t, hasFinding(r, "SkipHoursFormatted", "rss-channel-skiphours"))
+	assert.True(t, hasFinding(r, "SkipDaysFormatted", "rss-channel-skipdays"))
+	assert.True(t, hasFinding(r, "AtomLink", "rss-channel-hub-self"))
+}
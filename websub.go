@@ -0,0 +1,123 @@
+package podcast
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Specifications: https://www.w3.org/TR/websub/
+//
+
+// AddHub adds a WebSub hub, as an atom:link with rel="hub", that
+// aggregators subscribe to for instant notification of new episodes.
+// Calling this method multiple times will APPEND the hub to the list.
+//
+// The hubs are notified of changes to the feed with a Publisher.
+func (p *Podcast) AddHub(href string) {
+	if len(href) == 0 {
+		return
+	}
+	p.AtomLinks = append(p.AtomLinks, &AtomLink{
+		HREF: href,
+		Rel:  "hub",
+	})
+}
+
+// Hubs returns the URLs of the WebSub hubs added with AddHub.
+func (p *Podcast) Hubs() []string {
+	var hubs []string
+	for _, l := range p.AtomLinks {
+		if l.Rel == "hub" {
+			hubs = append(hubs, l.HREF)
+		}
+	}
+	return hubs
+}
+
+// Publisher notifies WebSub hubs that a feed has changed, such as after a
+// new episode is encoded, by POSTing hub.mode=publish with the feed URL
+// as the hub.url.
+//
+// Requests failing with a network error, 429 Too Many Requests or a 5xx
+// status are retried, waiting Backoff and then twice as long before each
+// retry.
+type Publisher struct {
+	// Client sends the notifications, defaulting to http.DefaultClient.
+	Client *http.Client
+
+	// Retries is the number of times a failed notification is retried.
+	Retries int
+
+	// Backoff is the wait before the first retry.
+	Backoff time.Duration
+}
+
+// NewPublisher returns a Publisher retrying 3 times from 1 second.
+func NewPublisher() *Publisher {
+	return &Publisher{Retries: 3, Backoff: time.Second}
+}
+
+// Publish notifies each of the Hubs of the Podcast that the feed at its
+// AtomLink has changed.  Every hub is notified even when some fail, and
+// the first error is returned.
+func (pub *Publisher) Publish(p *Podcast) error {
+	if p.AtomLink == nil || len(p.AtomLink.HREF) == 0 {
+		return errors.New("podcast.Publisher.Publish: AtomLink is required")
+	}
+	var first error
+	for _, hub := range p.Hubs() {
+		if err := pub.PublishURL(hub, p.AtomLink.HREF); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// PublishURL notifies the hub that the feed at topic has changed.
+func (pub *Publisher) PublishURL(hub, topic string) error {
+	client := pub.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	form := url.Values{"hub.mode": {"publish"}, "hub.url": {topic}}.Encode()
+
+	wait := pub.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := publish(client, hub, form)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= pub.Retries {
+			return errors.Wrap(err, "podcast.Publisher.PublishURL: "+hub)
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// publish sends the form to the hub, reporting whether a failure may be
+// retried.
+var publish = func(client *http.Client, hub, form string) (bool, error) {
+	resp, err := client.Post(hub, "application/x-www-form-urlencoded",
+		strings.NewReader(form))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, errors.New("hub returned " + strconv.Itoa(resp.StatusCode))
+	}
+	return false, errors.New("hub returned " + strconv.Itoa(resp.StatusCode))
+}
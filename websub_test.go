@@ -0,0 +1,136 @@
+package podcast_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eduncan911/podcast"
+	"github.com/stretchr/testify/assert"
+)
+
+// hub returns a test WebSub hub answering with the statuses in turn,
+// then 204 No Content, counting the requests.
+func hub(t *testing.T, count *int32, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(count, 1)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "publish", r.FormValue("hub.mode"))
+		assert.Equal(t, "http://example.com/feed.rss", r.FormValue("hub.url"))
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func hubFeed(hubs ...string) *podcast.Podcast {
+	p := podcast.New("title", "http://example.com/", "description", &pubDate, &updatedDate)
+	p.AddAtomLink("http://example.com/feed.rss")
+	for _, h := range hubs {
+		p.AddHub(h)
+	}
+	return &p
+}
+
+func TestPublisherRetries(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var count int32
+	s := hub(t, &count, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	defer s.Close()
+	pub := &podcast.Publisher{Client: s.Client(), Retries: 2, Backoff: time.Millisecond}
+
+	// act
+	err := pub.Publish(hubFeed(s.URL))
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+}
+
+func TestPublisherRetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var count int32
+	s := hub(t, &count, 500, 500, 500)
+	defer s.Close()
+	pub := &podcast.Publisher{Client: s.Client(), Retries: 1, Backoff: time.Millisecond}
+
+	// act
+	err := pub.Publish(hubFeed(s.URL))
+
+	// assert
+	assert.EqualError(t, err, "podcast.Publisher.PublishURL: "+s.URL+": hub returned 500")
+	assert.EqualValues(t, 2, count)
+}
+
+func TestPublisherNoRetryOnClientError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	var bad, good int32
+	s1 := hub(t, &bad, http.StatusBadRequest)
+	defer s1.Close()
+	s2 := hub(t, &good)
+	defer s2.Close()
+	pub := &podcast.Publisher{Retries: 3, Backoff: time.Millisecond}
+
+	// act
+	err := pub.Publish(hubFeed(s1.URL, s2.URL))
+
+	// assert
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, bad)
+	assert.EqualValues(t, 1, good, "the other hubs are still notified")
+}
+
+func TestPublisherNetworkError(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	s := httptest.NewServer(http.NotFoundHandler())
+	s.Close()
+	pub := &podcast.Publisher{Retries: 1, Backoff: time.Millisecond}
+
+	// act
+	err := pub.PublishURL(s.URL, "http://example.com/feed.rss")
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestPublisherAtomLinkRequired(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := podcast.New("title", "http://example.com/", "description", nil, nil)
+	p.AddHub("http://hub.example.com/")
+
+	// act
+	err := podcast.NewPublisher().Publish(&p)
+
+	// assert
+	assert.EqualError(t, err, "podcast.Publisher.Publish: AtomLink is required")
+}
+
+func TestAddHub(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	p := hubFeed()
+
+	// act
+	p.AddHub("")
+	p.AddHub("http://hub1.example.com/")
+	p.AddHub("http://hub2.example.com/")
+
+	// assert
+	assert.EqualValues(t, []string{"http://hub1.example.com/", "http://hub2.example.com/"}, p.Hubs())
+	assert.Contains(t, p.String(), `<atom:link href="http://hub1.example.com/" rel="hub"></atom:link>`)
+}